@@ -17,7 +17,9 @@ package backend
 
 import (
 	"crypto/sha256"
+	"fmt"
 	"hash"
+	"time"
 
 	"github.com/consensys/gnark/constraint/solver"
 )
@@ -143,6 +145,8 @@ type VerifierConfig struct {
 	HashToFieldFn  hash.Hash
 	ChallengeHash  hash.Hash
 	KZGFoldingHash hash.Hash
+	SubgroupCheck  SubgroupCheckMethod
+	Metrics        Metrics
 }
 
 // NewVerifierConfig returns a default [VerifierConfig] with given verifier
@@ -194,3 +198,85 @@ func WithVerifierKZGFoldingHashFunction(hFunc hash.Hash) VerifierOption {
 		return nil
 	}
 }
+
+// SubgroupCheckMethod is the method used by the verifier to check that the
+// points of the proof are in the prime order subgroups of the curve.
+type SubgroupCheckMethod uint8
+
+const (
+	// SubgroupCheckEndomorphism uses the curve endomorphisms (GLV, ψ) as
+	// implemented by gnark-crypto IsInSubGroup. It is the default.
+	SubgroupCheckEndomorphism SubgroupCheckMethod = iota
+	// SubgroupCheckScalarMul checks explicitly that [r]P is the point at
+	// infinity, where r is the order of the subgroup. It is slower but does
+	// not rely on the curve specific endomorphism arguments.
+	SubgroupCheckScalarMul
+)
+
+// String returns the string representation of a subgroup check method
+func (m SubgroupCheckMethod) String() string {
+	switch m {
+	case SubgroupCheckEndomorphism:
+		return "endomorphism"
+	case SubgroupCheckScalarMul:
+		return "scalar-mul"
+	default:
+		return "unknown"
+	}
+}
+
+// WithVerifierSubgroupCheckMethod sets the method used to check that the proof
+// points are in the correct subgroups. If not set then by default
+// [SubgroupCheckEndomorphism] is used. Currently only the Groth16 verifier on
+// BLS12-381 supports [SubgroupCheckScalarMul], the Groth16 verifiers on the
+// other curves return an error for it.
+func WithVerifierSubgroupCheckMethod(method SubgroupCheckMethod) VerifierOption {
+	return func(pc *VerifierConfig) error {
+		switch method {
+		case SubgroupCheckEndomorphism, SubgroupCheckScalarMul:
+		default:
+			return fmt.Errorf("unknown subgroup check method %d", method)
+		}
+		pc.SubgroupCheck = method
+		return nil
+	}
+}
+
+// Metrics receives measurements from the verifiers, e.g. to export them to
+// Prometheus. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveDuration records the time spent in a verification stage.
+	ObserveDuration(stage string, d time.Duration)
+	// IncCounter increments the counter name.
+	IncCounter(name string)
+}
+
+// Stages and counters reported to [Metrics].
+const (
+	MetricsStageParse        = "parse"
+	MetricsStageSubgroup     = "subgroup"
+	MetricsStageCommitments  = "commitments"
+	MetricsStageMSM          = "msm"
+	MetricsStagePairing      = "pairing"
+	MetricsStageBatchPairing = "batch_pairing"
+
+	MetricsVerifySuccess = "verify_success"
+	MetricsVerifyFailure = "verify_failure"
+	MetricsBatchFallback = "batch_fallback"
+)
+
+// NopMetrics is a [Metrics] discarding all measurements.
+type NopMetrics struct{}
+
+func (NopMetrics) ObserveDuration(string, time.Duration) {}
+func (NopMetrics) IncCounter(string)                     {}
+
+// WithVerifierMetrics sets the [Metrics] the verifier reports the duration of
+// its stages and its results to. If not set, no measurement is taken at all.
+// Currently only the Groth16 verifiers report metrics.
+func WithVerifierMetrics(m Metrics) VerifierOption {
+	return func(pc *VerifierConfig) error {
+		pc.Metrics = m
+		return nil
+	}
+}
@@ -0,0 +1,27 @@
+//go:build debug
+// +build debug
+
+package groth16
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+)
+
+// ReadVerifyingKeyAnnotatedHex parses a human-readable dump of an arkworks
+// VerifyingKey where each point is hex encoded after its arkworks field label
+// (alpha_g1, beta_g2, gamma_g2, delta_g2, gamma_abc_g1). Other lines are
+// ignored, so a dump interleaving the hex with annotations can be pasted as is.
+//
+// This is a debugging helper, only available with the debug build tag.
+func ReadVerifyingKeyAnnotatedHex(curveID ecc.ID, text string) (VerifyingKey, error) {
+	switch curveID {
+	case ecc.BLS12_381:
+		return groth16_bls12381.ReadVerifyingKeyAnnotatedHex(text)
+	default:
+		return nil, fmt.Errorf("annotated verifying keys are not supported for %s", curveID)
+	}
+}
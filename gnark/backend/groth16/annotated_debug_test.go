@@ -0,0 +1,77 @@
+//go:build debug
+// +build debug
+
+package groth16
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadVerifyingKeyAnnotatedHex(t *testing.T) {
+	const (
+		g1 = bls12381.SizeOfG1AffineUncompressed
+		g2 = bls12381.SizeOfG2AffineUncompressed
+	)
+	vkBytes, err := base64.StdEncoding.DecodeString(arkworksVK)
+	require.NoError(t, err)
+	proofBytes, err := base64.StdEncoding.DecodeString(arkworksProof)
+	require.NoError(t, err)
+
+	// render the fixture the way a Rust {:#?} dump annotated by hand would look
+	alpha, vkBytes := vkBytes[:g1], vkBytes[g1:]
+	beta, vkBytes := vkBytes[:g2], vkBytes[g2:]
+	gamma, vkBytes := vkBytes[:g2], vkBytes[g2:]
+	delta, vkBytes := vkBytes[:g2], vkBytes[g2:]
+	k := vkBytes[8:] // skip the uint64 length of gamma_abc_g1
+	var sb strings.Builder
+	sb.WriteString("VerifyingKey {\n")
+	fmt.Fprintf(&sb, "    alpha_g1: 0x%x,\n", alpha)
+	sb.WriteString("    // G2 elements\n")
+	fmt.Fprintf(&sb, "    beta_g2: 0x%x,\n", beta)
+	fmt.Fprintf(&sb, "    gamma_g2 = %x\n", gamma)
+	fmt.Fprintf(&sb, "    vk.delta_g2: 0x%x,\n", delta)
+	sb.WriteString("    note: 2 public inputs (incl. one wire)\n")
+	fmt.Fprintf(&sb, "    gamma_abc_g1[1]: 0x%x\n", k[g1:])
+	fmt.Fprintf(&sb, "    gamma_abc_g1[0]: 0x%x\n", k[:g1])
+	sb.WriteString("}\n")
+
+	vk, err := ReadVerifyingKeyAnnotatedHex(ecc.BLS12_381, sb.String())
+	require.NoError(t, err)
+	require.Equal(t, 1, vk.NbPublicWitness())
+
+	proof := new(groth16_bls12381.Proof)
+	_, err = proof.Ar.SetBytes(proofBytes[:g1])
+	require.NoError(t, err)
+	_, err = proof.Bs.SetBytes(proofBytes[g1 : g1+g2])
+	require.NoError(t, err)
+	_, err = proof.Krs.SetBytes(proofBytes[g1+g2:])
+	require.NoError(t, err)
+
+	inputs, err := base64.StdEncoding.DecodeString(arkworksInputs)
+	require.NoError(t, err)
+	publicWitness, err := NewInputParser(ecc.BLS12_381).ParseBytes(inputs)
+	require.NoError(t, err)
+
+	require.NoError(t, Verify(proof, vk, publicWitness))
+
+	// the entries of gamma_abc_g1 can also be given as a list
+	list := fmt.Sprintf("alpha_g1: %x\nbeta_g2: %x\ngamma_g2: %x\ndelta_g2: %x\ngamma_abc_g1: [0x%x, 0x%x]\n",
+		alpha, beta, gamma, delta, k[:g1], k[g1:])
+	vk2, err := ReadVerifyingKeyAnnotatedHex(ecc.BLS12_381, list)
+	require.NoError(t, err)
+	require.NoError(t, Verify(proof, vk2, publicWitness))
+
+	_, err = ReadVerifyingKeyAnnotatedHex(ecc.BLS12_381, strings.Replace(list, "gamma_g2", "gamma", 1))
+	require.ErrorContains(t, err, "missing gamma_g2")
+	_, err = ReadVerifyingKeyAnnotatedHex(ecc.BN254, list)
+	require.Error(t, err)
+}
@@ -0,0 +1,9 @@
+package groth16
+
+// fixture of TestVerifyArkworksProof, serialized with arkworks CanonicalSerialize
+// (uncompressed): a BLS12-381 VerifyingKey, Proof and Vec of public inputs.
+const (
+	arkworksVK     = "GBn2MvqNck41HSUIHqMczzeZkawlyQZm4HED//sELtkcdjUc1aJAQbQOJtIxpQh+AVTOB9GnMvI83biSUy7C7Bf38u9DkIjowyI3ZReIDv/FrXQ1jnTSeb0jYJHyoDMwBx82qZbHGolJn/6Zqn0/lN7N0sqLBw27Rn5C0lqtkYr27JTWGwuJnI9ySytUnZn8FiOg5Rts++oiDnDn2lgDyK0RRKZ/mJNKa/KIHsZAdnj9UnEUZq1gjWdsYDGaKZgkCu0z9pf/JeBlCUU0ZCUg4IYqvfiYaR98+I9XvcrUT47SG3YIuZtK62E2cpzJcvDeDh+Ct0TbJFm6PdNyxl19cZIjJvFI4Efi9AF9m81OdE91ISmJb0ZZzMwf9kuSF9oIFnUNhEVZavjWeUh8cmeulzSurFhKzhkdIlaAoY7P+Ouubdal/WjkQUsWERZJBO4SA2PCtJ8zqHPWz8JiSbZjJ6DeA+ZzuBOfeYCei2QVhs3plD+gcu5e1wHIGz/UJsIgBYeX1RcKOZ3ppkDH+Zp0YsGtifrwUAURzOSE4OFi7AEEHChTXsx+6PNQWrholqAQB+bnGDuY+fTRHVNUhJZWfjwCoFs5SA2rXXJV1yrL2ArmxNqrDx0M1nbBZf5Zodo/DTrIMvJQivbwGHKtqH6mbS+1sJnTTFusgedILJVidt/CNMjSr1/SOUtUQNBwiiyfEkpTwHVelZXPn4ra3l3u/LildKZ969O3TQjEnCPdwUzW1Itl3OUAyKXTMOdg/oW7DDF00ZX1MIp2uQJq02n0EmA0bXpj6gVE9g29+gZChumInBHOCpCuX0WHdtT10zKEDjNCO+2k3gW+2JkA3ofJaiOw2c5O6L3gwk4HZL74tkEjFFrFcXwYP0S2DPAhcvuYAgAAAAAAAAAQ33YNDy1n/f9p0O06BlPdiAjfPEB+pNDif4YSw/u3SMtDctM8rFEu5e9O4Wg8P+UXuaeEY4tHnmcR24kZIFQz6Kb1oGmAoy5+nzvopLE8EQjORO08C0ySeogOw6eU2tcW7IDWsQULv8IJ9ydniszoeIwFR1dx2v/dRErYeGx6QBldhZhQ/i5yvjBU6fuM6AUMLMM7yDkTaVTDM8Y5B9GiivtkFzKhqZsfd8UJ2qzxisg5nVS1+PqfilZ0jA9B3YU="
+	arkworksProof  = "DM+KjDng6p+qO2M7/uw+ES+N+wgXeG/WjuAzb3ltP4+UYGqMjxxSfVEtm2kI8lfeAitCtvzAGLJHu/hGnW+9Efmr+8OWFc+bhg2VxbfoBxU1pisUJFfbKzdZgOjdMSPdDmlMjptygC9Q5GiAacW5laWpAjcrsSzbHkk/nnnFtFBy1sSQ+Kuqrlc9IabSb8JsErJ9JulK5/kb1z4YcCB6MOiW+SOSFZuVYIE8zv1C0mDrBGQTp4K1fhkjP1Pwr6LsDawdZX6TDYznHHDErAT0g+L277qYukZQzDqyXaKGUTdt8MJjmAaDf11VMoJeV+hCBMb3vT4NijzZxIGcy0iV1ROR7EXssXBfEfTfsNAUTR+yIVcjudM+l9wZ/OzwbTi6AdD1sH8SPh30KncZT3Tlqmm/WSZ+ToCSsie9xrfX4fCZePzjFMmt5bP2s6KsN+0EEzryX5r1E01OiyJPQSNcdq12JQ8Sp0Kp8cz4YwhhvqrDrkCtKB62ZHna+WQHmwUZ"
+	arkworksInputs = "AQAAAAAAAABvP35ar9waPuSngei09jMmzuvh5vqc5qI/lADfug14UQ=="
+)
@@ -0,0 +1,97 @@
+package groth16
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// auditLog writes timestamped lines to w and keeps the first write error.
+type auditLog struct {
+	w   io.Writer
+	err error
+}
+
+func (l *auditLog) printf(format string, args ...any) {
+	if l.err != nil {
+		return
+	}
+	_, l.err = fmt.Fprintf(l.w, "%s %s\n", time.Now().UTC().Format(time.RFC3339Nano), fmt.Sprintf(format, args...))
+}
+
+// sha256Of returns the hex encoded SHA-256 of the bytes written by writeTo.
+func sha256Of(writeTo func(io.Writer) (int64, error)) (string, error) {
+	h := sha256.New()
+	if _, err := writeTo(h); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifyWithAuditLog runs Verify and writes to w a self-contained, timestamped
+// record of the verification: the implementation verifying it (see
+// VerifyEnvironment), the SHA-256 of the verifying key and proof (raw
+// encoding) and of the public witness (binary encoding), the number of public
+// inputs, the result of each check and a final line
+//
+//	<timestamp> result: VALID
+//
+// or "result: INVALID (<reason>)". Unlike the debug logger, the record is meant
+// to be archived. The returned error is the verification error if any, then the
+// error writing to w.
+func VerifyWithAuditLog(proof Proof, vk VerifyingKey, publicWitness witness.Witness, w io.Writer, opts ...backend.VerifierOption) error {
+	log := &auditLog{w: w}
+	log.printf("groth16 verification on %s", vk.CurveID())
+	log.printf("environment: %s", VerifyEnvironment())
+
+	err := func() error {
+		vkHash, err := sha256Of(vk.WriteRawTo)
+		if err != nil {
+			return fmt.Errorf("hash verifying key: %w", err)
+		}
+		log.printf("verifying key sha256: %s", vkHash)
+		proofHash, err := sha256Of(proof.WriteRawTo)
+		if err != nil {
+			return fmt.Errorf("hash proof: %w", err)
+		}
+		log.printf("proof sha256: %s", proofHash)
+		inputsHash, err := sha256Of(publicWitness.WriteTo)
+		if err != nil {
+			return fmt.Errorf("hash public witness: %w", err)
+		}
+		log.printf("public inputs sha256: %s", inputsHash)
+
+		nbPublic := reflect.ValueOf(publicWitness.Vector()).Len()
+		log.printf("public inputs: %d, expected %d", nbPublic, vk.NbPublicWitness())
+		if proof.CurveID() != vk.CurveID() {
+			log.printf("check curve: failed, proof is on %s", proof.CurveID())
+			return fmt.Errorf("proof is on %s, verifying key on %s", proof.CurveID(), vk.CurveID())
+		}
+		log.printf("check curve: ok")
+		if nbPublic != vk.NbPublicWitness() {
+			log.printf("check input count: failed")
+			return fmt.Errorf("invalid witness size, got %d, expected %d", nbPublic, vk.NbPublicWitness())
+		}
+		log.printf("check input count: ok")
+
+		if err := Verify(proof, vk, publicWitness, opts...); err != nil {
+			log.printf("check proof (subgroups, commitments, pairing): failed: %v", err)
+			return err
+		}
+		log.printf("check proof (subgroups, commitments, pairing): ok")
+		return nil
+	}()
+
+	if err != nil {
+		log.printf("result: INVALID (%v)", err)
+		return err
+	}
+	log.printf("result: VALID")
+	return log.err
+}
@@ -0,0 +1,54 @@
+package groth16_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func TestVerifyWithAuditLog(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &snarkjsCircuit{})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	fullWitness, err := frontend.NewWitness(&snarkjsCircuit{X: 3, Y: 9, Z: 12}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, fullWitness)
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+
+	var vkBuf, inputsBuf bytes.Buffer
+	_, err = vk.WriteRawTo(&vkBuf)
+	assert.NoError(err)
+	_, err = publicWitness.WriteTo(&inputsBuf)
+	assert.NoError(err)
+	vkHash, inputsHash := sha256.Sum256(vkBuf.Bytes()), sha256.Sum256(inputsBuf.Bytes())
+
+	var log bytes.Buffer
+	assert.NoError(groth16.VerifyWithAuditLog(proof, vk, publicWitness, &log))
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	assert.Contains(log.String(), "verifying key sha256: "+hex.EncodeToString(vkHash[:]))
+	assert.Contains(log.String(), "public inputs sha256: "+hex.EncodeToString(inputsHash[:]))
+	assert.Contains(log.String(), "public inputs: 2, expected 2")
+	assert.Contains(log.String(), "environment: "+groth16.VerifyEnvironment().String())
+	assert.True(strings.HasSuffix(lines[len(lines)-1], " result: VALID"), lines[len(lines)-1])
+
+	wrongWitness, err := frontend.NewWitness(&snarkjsCircuit{Y: 9, Z: 13}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	assert.NoError(err)
+	log.Reset()
+	assert.Error(groth16.VerifyWithAuditLog(proof, vk, wrongWitness, &log))
+	lines = strings.Split(strings.TrimSpace(log.String()), "\n")
+	assert.Contains(log.String(), "check input count: ok")
+	assert.Contains(lines[len(lines)-1], " result: INVALID (")
+}
@@ -0,0 +1,166 @@
+package groth16
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633"
+	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// base58Alphabet is the Bitcoin alphabet, used by Solana and IPFS.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// maxBase58Inputs is the largest number of public inputs of the verifying keys
+// read from Base58.
+const maxBase58Inputs = 1 << 12
+
+var base58Digits = func() (digits [128]int8) {
+	for i := range digits {
+		digits[i] = -1
+	}
+	for i, c := range base58Alphabet {
+		digits[c] = int8(i)
+	}
+	return
+}()
+
+// ReadProofBase58 decodes a Base58 encoded proof (Bitcoin alphabet) and reads
+// it with ReadFrom, in the format of NewProof(curveID).
+func ReadProofBase58(curveID ecc.ID, s string) (Proof, error) {
+	proof := NewProof(curveID)
+	if err := readBase58(proof.ReadFrom, s, maxBase58Length(curveID)); err != nil {
+		return nil, fmt.Errorf("proof: %w", err)
+	}
+	return proof, nil
+}
+
+// ReadVerifyingKeyBase58 decodes a Base58 encoded verifying key (Bitcoin
+// alphabet) and reads it with ReadFrom, in the format of
+// NewVerifyingKey(curveID), except BLS12-381 keys, read as arkworks keys with
+// ReadArkworksVerifyingKey.
+func ReadVerifyingKeyBase58(curveID ecc.ID, s string) (VerifyingKey, error) {
+	if curveID == ecc.BLS12_381 {
+		data, err := decodeBase58(s, maxBase58Length(curveID))
+		if err != nil {
+			return nil, fmt.Errorf("verifying key: %w", err)
+		}
+		vk, err := ReadArkworksVerifyingKey(curveID, data)
+		if err != nil {
+			return nil, fmt.Errorf("verifying key: %w", err)
+		}
+		return vk, nil
+	}
+	vk := NewVerifyingKey(curveID)
+	if err := readBase58(vk.ReadFrom, s, maxBase58Length(curveID)); err != nil {
+		return nil, fmt.Errorf("verifying key: %w", err)
+	}
+	return vk, nil
+}
+
+// ReadInputsBase58 decodes Base58 encoded public inputs (Bitcoin alphabet)
+// serialized as an arkworks Vec<F>, see NewInputParser.
+func ReadInputsBase58(curveID ecc.ID, s string) (witness.Witness, error) {
+	data, err := decodeBase58(s, maxBase58Length(curveID))
+	if err != nil {
+		return nil, fmt.Errorf("public inputs: %w", err)
+	}
+	return NewInputParser(curveID).ParseBytes(data)
+}
+
+// readBase58 decodes s and reads the result with readFrom, which must consume
+// all of it.
+func readBase58(readFrom func(io.Reader) (int64, error), s string, maxLength int) error {
+	data, err := decodeBase58(s, maxLength)
+	if err != nil {
+		return err
+	}
+	n, err := readFrom(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if int(n) != len(data) {
+		return fmt.Errorf("%d trailing bytes", len(data)-int(n))
+	}
+	return nil
+}
+
+// maxBase58Length returns the length of the Base58 encoding of the largest
+// valid verifying key of curveID: uncompressed, with maxBase58Inputs public
+// inputs and a commitment to all of them. Proofs and public inputs are
+// smaller. Longer strings are rejected before decoding, whose time is
+// quadratic in their length.
+func maxBase58Length(curveID ecc.ID) int {
+	var g1, g2 int
+	switch curveID {
+	case ecc.BN254:
+		g1, g2 = bn254.SizeOfG1AffineUncompressed, bn254.SizeOfG2AffineUncompressed
+	case ecc.BLS12_377:
+		g1, g2 = bls12377.SizeOfG1AffineUncompressed, bls12377.SizeOfG2AffineUncompressed
+	case ecc.BLS12_381:
+		g1, g2 = bls12381.SizeOfG1AffineUncompressed, bls12381.SizeOfG2AffineUncompressed
+	case ecc.BW6_761:
+		g1, g2 = bw6761.SizeOfG1AffineUncompressed, bw6761.SizeOfG2AffineUncompressed
+	case ecc.BLS24_317:
+		g1, g2 = bls24317.SizeOfG1AffineUncompressed, bls24317.SizeOfG2AffineUncompressed
+	case ecc.BLS24_315:
+		g1, g2 = bls24315.SizeOfG1AffineUncompressed, bls24315.SizeOfG2AffineUncompressed
+	case ecc.BW6_633:
+		g1, g2 = bw6633.SizeOfG1AffineUncompressed, bw6633.SizeOfG2AffineUncompressed
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+	size := (5+maxBase58Inputs)*g1 + // α, β, δ, then K: the constant, the inputs and the commitment
+		5*g2 + // β, γ, δ and the commitment key
+		12 + 8*maxBase58Inputs // lengths of K and of the committed indexes, the indexes
+	return size*138/100 + 1 // log(256)/log(58) < 1.38
+}
+
+// decodeBase58 decodes s, ignoring surrounding white space. Each leading '1'
+// is a zero byte. Strings longer than maxLength are rejected.
+func decodeBase58(s string, maxLength int) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("empty base58 string")
+	}
+	if len(s) > maxLength {
+		return nil, fmt.Errorf("base58 string too long: %d characters, at most %d", len(s), maxLength)
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	// little-endian base 256 digits of the number
+	var num []byte
+	for i, c := range s {
+		if c >= 128 || base58Digits[c] < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q at offset %d", c, i)
+		}
+		carry := int(base58Digits[c])
+		for j := range num {
+			carry += int(num[j]) * 58
+			num[j] = byte(carry)
+			carry >>= 8
+		}
+		for ; carry > 0; carry >>= 8 {
+			num = append(num, byte(carry))
+		}
+	}
+
+	res := make([]byte, zeros+len(num))
+	for i, b := range num {
+		res[len(res)-1-i] = b
+	}
+	return res, nil
+}
@@ -0,0 +1,123 @@
+package groth16
+
+import (
+	"bytes"
+	"encoding/base64"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeBase58 is the reference encoding with the Bitcoin alphabet.
+func encodeBase58(data []byte) string {
+	var sb strings.Builder
+	n, rem, radix := new(big.Int).SetBytes(data), new(big.Int), big.NewInt(58)
+	for n.Sign() > 0 {
+		n.QuoRem(n, radix, rem)
+		sb.WriteByte(base58Alphabet[rem.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		sb.WriteByte(base58Alphabet[0])
+	}
+	res := []byte(sb.String())
+	reverse(res)
+	return string(res)
+}
+
+func TestDecodeBase58(t *testing.T) {
+	for s, expected := range map[string][]byte{
+		"2NEpo7TZRRrLZSi2U": []byte("Hello World!"),
+		"1112":              {0, 0, 0, 1},
+		"1":                 {0},
+		"5Q":                {0xff},
+		" 5Q\n":             {0xff},
+	} {
+		data, err := decodeBase58(s, 32)
+		require.NoError(t, err, s)
+		require.Equal(t, expected, data, s)
+	}
+	for _, s := range []string{"", "0", "5Ol", "2NEpo7TZRRrLZSi2U+", "5é"} {
+		_, err := decodeBase58(s, 32)
+		require.Error(t, err, s)
+	}
+	_, err := decodeBase58("2NEp0", 32)
+	require.ErrorContains(t, err, `'0' at offset 4`)
+	_, err = decodeBase58(" 2NEpo7TZRRrLZSi2U\n", 17)
+	require.NoError(t, err)
+	_, err = decodeBase58("2NEpo7TZRRrLZSi2U", 16)
+	require.ErrorContains(t, err, "too long")
+}
+
+func TestReadBase58(t *testing.T) {
+	decode := func(s string) []byte {
+		data, err := base64.StdEncoding.DecodeString(s)
+		require.NoError(t, err)
+		return data
+	}
+
+	proofBytes := decode(arkworksProof)
+	expectedProof := NewProof(ecc.BLS12_381)
+	_, err := expectedProof.ReadFrom(bytes.NewReader(proofBytes))
+	require.NoError(t, err)
+	proof, err := ReadProofBase58(ecc.BLS12_381, encodeBase58(proofBytes))
+	require.NoError(t, err)
+	require.Equal(t, expectedProof, proof)
+	_, err = ReadProofBase58(ecc.BLS12_381, encodeBase58(append(proofBytes, 0)))
+	require.ErrorContains(t, err, "trailing")
+
+	vkBytes := decode(arkworksVK)
+	expectedVK, err := ReadArkworksVerifyingKey(ecc.BLS12_381, vkBytes)
+	require.NoError(t, err)
+	vk, err := ReadVerifyingKeyBase58(ecc.BLS12_381, encodeBase58(vkBytes))
+	require.NoError(t, err)
+	require.Equal(t, expectedVK, vk)
+	_, err = ReadVerifyingKeyBase58(ecc.BLS12_381, encodeBase58(append(vkBytes, 0)))
+	require.ErrorIs(t, err, ErrStructureMismatch)
+
+	inputBytes := decode(arkworksInputs)
+	expectedInputs, err := NewInputParser(ecc.BLS12_381).ParseBytes(inputBytes)
+	require.NoError(t, err)
+	inputs, err := ReadInputsBase58(ecc.BLS12_381, encodeBase58(inputBytes))
+	require.NoError(t, err)
+	require.Equal(t, expectedInputs.Vector(), inputs.Vector())
+
+	_, err = ReadInputsBase58(ecc.BLS12_381, "invalid")
+	require.ErrorContains(t, err, "invalid base58 character 'l'")
+
+	tooLong := strings.Repeat("2", maxBase58Length(ecc.BLS12_381)+1)
+	_, err = ReadVerifyingKeyBase58(ecc.BLS12_381, tooLong)
+	require.ErrorContains(t, err, "too long")
+	_, err = ReadProofBase58(ecc.BLS12_381, tooLong)
+	require.ErrorContains(t, err, "too long")
+}
+
+func TestBase58MaxLength(t *testing.T) {
+	// the largest key read from Base58 fits
+	var vk groth16_bn254.VerifyingKey
+	_, _, g1, g2 := bn254.Generators()
+	vk.G1.K = make([]bn254.G1Affine, maxBase58Inputs+2)
+	for i := range vk.G1.K {
+		vk.G1.K[i] = g1
+	}
+	vk.G2.Beta, vk.G2.Gamma, vk.G2.Delta = g2, g2, g2
+	committed := make([]int, maxBase58Inputs)
+	for i := range committed {
+		committed[i] = i + 1
+	}
+	vk.PublicAndCommitmentCommitted = [][]int{committed}
+	var buf bytes.Buffer
+	_, err := vk.WriteRawTo(&buf)
+	require.NoError(t, err)
+	// a Base58 digit holds log2(58) bits, a leading zero byte is one digit
+	length := int(math.Ceil(float64(8*buf.Len()) / math.Log2(58)))
+	require.LessOrEqual(t, length, maxBase58Length(ecc.BN254))
+}
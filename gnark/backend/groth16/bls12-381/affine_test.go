@@ -0,0 +1,30 @@
+package groth16
+
+import (
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofFromAffine(t *testing.T) {
+	proof, vk, publicWitness := proofFixture(t)
+
+	built, err := ProofFromAffine(proof.Ar, proof.Bs, proof.Krs)
+	require.NoError(t, err)
+	require.Equal(t, proof.Ar, built.Ar)
+	require.Equal(t, proof.Bs, built.Bs)
+	require.Equal(t, proof.Krs, built.Krs)
+	require.NoError(t, Verify(built, vk, publicWitness))
+
+	p1, p2 := outsideSubgroup(t)
+	_, err = ProofFromAffine(p1, proof.Bs, proof.Krs)
+	require.ErrorIs(t, err, errCorrectSubgroupCheckFailed)
+	require.ErrorContains(t, err, "a: ")
+	_, err = ProofFromAffine(proof.Ar, p2, proof.Krs)
+	require.ErrorContains(t, err, "b: ")
+	_, err = ProofFromAffineWithCommitments(proof.Ar, proof.Bs, proof.Krs, []curve.G1Affine{proof.Ar}, p1)
+	require.ErrorContains(t, err, "proof of knowledge")
+	_, err = ProofFromAffineWithCommitments(proof.Ar, proof.Bs, proof.Krs, []curve.G1Affine{p1}, proof.Ar)
+	require.ErrorContains(t, err, "commitment 0: ")
+}
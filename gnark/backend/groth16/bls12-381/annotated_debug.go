@@ -0,0 +1,111 @@
+//go:build debug
+// +build debug
+
+package groth16
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+var (
+	annotationRegexp = regexp.MustCompile(`(?m)^[\s{,]*([A-Za-z_][A-Za-z0-9_.]*)(?:\[(\d+)\])?\s*[:=]\s*(.*)$`)
+	hexRegexp        = regexp.MustCompile(`\b(?:0x)?([0-9a-fA-F]{64,})\b`)
+)
+
+// ReadVerifyingKeyAnnotatedHex parses a human-readable dump of an arkworks
+// VerifyingKey, where each field is labelled with its arkworks name:
+//
+//	alpha_g1: 0x17f1...
+//	beta_g2: 0x13e0...
+//	gamma_g2: 0x024a...
+//	delta_g2: 0x0ab8...
+//	gamma_abc_g1: [0x0f32..., 0x1183...]
+//
+// gamma_abc_g1 entries may also be given one per line as gamma_abc_g1[i].
+// Points are hex encoded, compressed or not, and lines with other labels or no
+// label at all are ignored.
+//
+// This is a debugging helper, only available with the debug build tag.
+func ReadVerifyingKeyAnnotatedHex(text string) (*VerifyingKey, error) {
+	var (
+		vk    VerifyingKey
+		found = make(map[string]bool)
+		k     = make(map[int]curve.G1Affine)
+	)
+
+	for _, m := range annotationRegexp.FindAllStringSubmatch(text, -1) {
+		label := m[1]
+		if i := strings.LastIndexByte(label, '.'); i >= 0 {
+			label = label[i+1:] // vk.alpha_g1
+		}
+		var points [][]byte
+		for _, h := range hexRegexp.FindAllStringSubmatch(m[3], -1) {
+			b, err := hex.DecodeString(h[1])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", m[1], err)
+			}
+			points = append(points, b)
+		}
+		if len(points) == 0 {
+			continue
+		}
+
+		var err error
+		switch label {
+		case "alpha_g1":
+			_, err = vk.G1.Alpha.SetBytes(points[0])
+		case "beta_g2":
+			_, err = vk.G2.Beta.SetBytes(points[0])
+		case "gamma_g2":
+			_, err = vk.G2.Gamma.SetBytes(points[0])
+		case "delta_g2":
+			_, err = vk.G2.Delta.SetBytes(points[0])
+		case "gamma_abc_g1":
+			offset := 0
+			if m[2] != "" {
+				if offset, err = strconv.Atoi(m[2]); err != nil {
+					return nil, fmt.Errorf("%s: %w", m[1], err)
+				}
+			}
+			for i := range points {
+				var p curve.G1Affine
+				if _, err = p.SetBytes(points[i]); err != nil {
+					break
+				}
+				k[offset+i] = p
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m[1], err)
+		}
+		found[label] = true
+	}
+
+	for _, label := range []string{"alpha_g1", "beta_g2", "gamma_g2", "delta_g2", "gamma_abc_g1"} {
+		if !found[label] {
+			return nil, fmt.Errorf("missing %s", label)
+		}
+	}
+	vk.G1.K = make([]curve.G1Affine, len(k))
+	for i := range vk.G1.K {
+		p, ok := k[i]
+		if !ok {
+			return nil, fmt.Errorf("missing gamma_abc_g1[%d]", i)
+		}
+		vk.G1.K[i] = p
+	}
+	vk.PublicAndCommitmentCommitted = [][]int{}
+
+	if err := vk.Precompute(); err != nil {
+		return nil, err
+	}
+	return &vk, nil
+}
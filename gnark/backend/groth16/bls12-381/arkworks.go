@@ -0,0 +1,126 @@
+package groth16
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// ParseVerifyingKey decodes an arkworks verifying key: alpha_g1, beta_g2,
+// gamma_g2, delta_g2, then the number of gamma_abc_g1 entries as a
+// little-endian uint64 followed by the entries, each point compressed or not.
+// The key may be followed by its input labels section (see
+// WriteInputLabelsTo), and nothing else. WriteArkworksTo writes this layout.
+func ParseVerifyingKey(data []byte) (*VerifyingKey, error) {
+	vk := new(VerifyingKey)
+	rest := data
+	setBytes := func(name string, p interface{ SetBytes([]byte) (int, error) }) error {
+		n, err := p.SetBytes(rest)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		rest = rest[n:]
+		return nil
+	}
+	if err := setBytes("alpha_g1", &vk.G1.Alpha); err != nil {
+		return nil, err
+	}
+	for _, e := range []struct {
+		name string
+		p    *curve.G2Affine
+	}{{"beta_g2", &vk.G2.Beta}, {"gamma_g2", &vk.G2.Gamma}, {"delta_g2", &vk.G2.Delta}} {
+		if err := setBytes(e.name, e.p); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(rest) < 8 {
+		return nil, errors.New("gamma_abc_g1 length: truncated")
+	}
+	n := binary.LittleEndian.Uint64(rest)
+	rest = rest[8:]
+	if n == 0 || n > uint64(len(rest)/curve.SizeOfG1AffineCompressed) {
+		return nil, fmt.Errorf("invalid gamma_abc_g1 length %d", n)
+	}
+	vk.G1.K = make([]curve.G1Affine, n)
+	for i := range vk.G1.K {
+		if err := setBytes(fmt.Sprintf("gamma_abc_g1[%d]", i), &vk.G1.K[i]); err != nil {
+			return nil, err
+		}
+	}
+	vk.PublicAndCommitmentCommitted = [][]int{}
+
+	if len(rest) != 0 {
+		read, err := vk.ReadInputLabelsFrom(bytes.NewReader(rest))
+		if err != nil {
+			return nil, fmt.Errorf("input labels: %w", err)
+		}
+		if int(read) != len(rest) {
+			return nil, fmt.Errorf("%d trailing bytes after the verifying key", len(rest)-int(read))
+		}
+	}
+
+	if err := vk.Precompute(); err != nil {
+		return nil, err
+	}
+	return vk, nil
+}
+
+// WriteArkworksTo writes vk in the arkworks layout read by ParseVerifyingKey,
+// points compressed, followed by the input labels if any (see
+// WriteInputLabelsTo). Keys with commitments have no arkworks encoding.
+func (vk *VerifyingKey) WriteArkworksTo(w io.Writer) (int64, error) {
+	return vk.writeArkworksTo(w, false)
+}
+
+// WriteArkworksRawTo is WriteArkworksTo with uncompressed points.
+func (vk *VerifyingKey) WriteArkworksRawTo(w io.Writer) (int64, error) {
+	return vk.writeArkworksTo(w, true)
+}
+
+func (vk *VerifyingKey) writeArkworksTo(w io.Writer, raw bool) (int64, error) {
+	if vk.preparedOnly() {
+		return 0, errPreparedOnly
+	}
+	if !vk.serializable() {
+		return 0, errNotSerializable
+	}
+	if len(vk.PublicAndCommitmentCommitted) != 0 {
+		return 0, errors.New("verifying key with commitments has no arkworks encoding")
+	}
+	var buf bytes.Buffer
+	writeG1 := func(p *curve.G1Affine) {
+		if raw {
+			b := p.RawBytes()
+			buf.Write(b[:])
+		} else {
+			b := p.Bytes()
+			buf.Write(b[:])
+		}
+	}
+	writeG1(&vk.G1.Alpha)
+	for _, p := range []*curve.G2Affine{&vk.G2.Beta, &vk.G2.Gamma, &vk.G2.Delta} {
+		if raw {
+			b := p.RawBytes()
+			buf.Write(b[:])
+		} else {
+			b := p.Bytes()
+			buf.Write(b[:])
+		}
+	}
+	buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(vk.G1.K))))
+	for i := range vk.G1.K {
+		writeG1(&vk.G1.K[i])
+	}
+
+	n, err := buf.WriteTo(w)
+	if err != nil {
+		return n, err
+	}
+	m, err := vk.WriteInputLabelsTo(w)
+	return n + m, err
+}
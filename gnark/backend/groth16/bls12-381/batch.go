@@ -0,0 +1,205 @@
+package groth16
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16/internal"
+)
+
+// Task is a proof to verify in a batch, with its verifying key and public
+// witness.
+type Task struct {
+	Proof         *Proof
+	VerifyingKey  *VerifyingKey
+	PublicWitness fr.Vector
+}
+
+// VerifySmartBatch verifies tasks and returns the error of each, nil for valid
+// proofs. Tasks are grouped by verifying key (by the hash of its raw
+// encoding), and groups are verified concurrently:
+//
+//   - proofs alone in their group, or whose verifying key has commitments, a
+//     split IC or no α and β, are verified with Verify;
+//   - other groups are checked at once with a random linear combination of
+//     their verification equations, one multi-Miller loop and one final
+//     exponentiation for the whole group. If the combination fails, the
+//     proofs of the group are verified one by one to find the invalid ones.
+//
+// Keys with a lazy IC (see VerifyingKeyWithLazyIC), a split IC (see
+// pairingTerms) or only prepared (see NewPreparedVerifyingKey) can't be
+// encoded and are grouped by pointer instead.
+//
+// The random combination is sound except with probability ~ 1/r for a group
+// holding an invalid proof, as the coefficients are unknown to the prover.
+func VerifySmartBatch(tasks []Task, opts ...backend.VerifierOption) []error {
+	return verifySmartBatch(tasks, nil, opts)
+}
+
+// batchGroupKey identifies the verifying key of a group of tasks: the hash of
+// its raw encoding, or its pointer if it can't be serialized.
+type batchGroupKey struct {
+	hash [sha256.Size]byte
+	vk   *VerifyingKey
+}
+
+// verifySmartBatch is VerifySmartBatch combining the equation of tasks[i]
+// with coefficients[i], or with random coefficients if nil.
+func verifySmartBatch(tasks []Task, coefficients []fr.Element, opts []backend.VerifierOption) []error {
+	errs := make([]error, len(tasks))
+
+	groups := make(map[batchGroupKey][]int)
+	var order []batchGroupKey
+	keys := make(map[*VerifyingKey]batchGroupKey)
+	for i := range tasks {
+		vk := tasks[i].VerifyingKey
+		k, ok := keys[vk]
+		if !ok && !vk.serializable() {
+			// grouped with the keys sharing its pointer
+			k = batchGroupKey{vk: vk}
+			keys[vk] = k
+		} else if !ok {
+			hasher := sha256.New()
+			if _, err := vk.WriteRawTo(hasher); err != nil {
+				errs[i] = fmt.Errorf("hash verifying key: %w", err)
+				continue
+			}
+			copy(k.hash[:], hasher.Sum(nil))
+			keys[vk] = k
+		}
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], i)
+	}
+
+	var wg sync.WaitGroup
+	for _, k := range order {
+		wg.Add(1)
+		go func(group []int) {
+			defer wg.Done()
+			verifyGroup(tasks, group, coefficients, errs, opts)
+		}(groups[k])
+	}
+	wg.Wait()
+	return errs
+}
+
+// verifyGroup verifies the tasks of group, which share a verifying key, and
+// sets their errors.
+func verifyGroup(tasks []Task, group []int, coefficients []fr.Element, errs []error, opts []backend.VerifierOption) {
+	vk := tasks[group[0]].VerifyingKey
+	if len(group) == 1 || len(vk.PublicAndCommitmentCommitted) != 0 || len(vk.icParts) != 0 || vk.preparedOnly() {
+		for _, i := range group {
+			errs[i] = Verify(tasks[i].Proof, tasks[i].VerifyingKey, tasks[i].PublicWitness, opts...)
+		}
+		return
+	}
+
+	opt, err := backend.NewVerifierConfig(opts...)
+	if err != nil {
+		for _, i := range group {
+			errs[i] = fmt.Errorf("new verifier config: %w", err)
+		}
+		return
+	}
+
+	// checks which don't need pairings are done per proof
+	timer := newStageTimer(opt.Metrics)
+	batch := make([]int, 0, len(group))
+	for _, i := range group {
+		if len(tasks[i].PublicWitness) != vk.nbIC()-1 {
+			errs[i] = fmt.Errorf("%w, got %d, expected %d (public - ONE_WIRE)", internal.ErrInvalidWitnessSize, len(tasks[i].PublicWitness), vk.nbIC()-1)
+			timer.count(errs[i])
+			continue
+		}
+		if !tasks[i].Proof.isValidWith(opt.SubgroupCheck) {
+			errs[i] = errCorrectSubgroupCheckFailed
+			timer.count(errs[i])
+			continue
+		}
+		batch = append(batch, i)
+	}
+	timer.done(backend.MetricsStageSubgroup)
+	if len(batch) == 0 {
+		return
+	}
+
+	err = batchPairingCheck(tasks, batch, coefficients)
+	timer.done(backend.MetricsStageBatchPairing)
+	if err != nil {
+		if opt.Metrics != nil {
+			opt.Metrics.IncCounter(backend.MetricsBatchFallback)
+		}
+		for _, i := range batch {
+			errs[i] = Verify(tasks[i].Proof, tasks[i].VerifyingKey, tasks[i].PublicWitness, opts...)
+		}
+		return
+	}
+	for range batch {
+		timer.count(nil)
+	}
+}
+
+// batchPairingCheck checks, for random ρᵢ or ρᵢ = coefficients[i] if given,
+//
+//	∏ e(ρᵢ·Arᵢ, Bsᵢ) · e(-(Σρᵢ)·α, β) · e(Σρᵢ·kSumᵢ, -γ) · e(Σρᵢ·Krsᵢ, -δ) == 1
+//
+// for proofs without commitments sharing a verifying key.
+func batchPairingCheck(tasks []Task, batch []int, coefficients []fr.Element) error {
+	vk := tasks[batch[0]].VerifyingKey
+	n := len(batch)
+
+	rho := make([]fr.Element, n)
+	kSums := make([]curve.G1Affine, n)
+	krs := make([]curve.G1Affine, n)
+	P := make([]curve.G1Affine, n, n+3)
+	Q := make([]curve.G2Affine, n, n+3)
+	var rhoSum fr.Element
+	for j, i := range batch {
+		if coefficients != nil {
+			rho[j] = coefficients[i]
+		} else if _, err := rho[j].SetRandom(); err != nil {
+			return err
+		}
+		rhoSum.Add(&rhoSum, &rho[j])
+
+		kSum, err := vk.publicInputPoint(tasks[i].PublicWitness)
+		if err != nil {
+			return err
+		}
+		kSums[j].FromJacobian(&kSum)
+		krs[j] = tasks[i].Proof.Krs
+
+		P[j].ScalarMultiplication(&tasks[i].Proof.Ar, rho[j].BigInt(new(big.Int)))
+		Q[j] = tasks[i].Proof.Bs
+	}
+
+	var alpha, kSum, krsSum curve.G1Affine
+	rhoSum.Neg(&rhoSum)
+	alpha.ScalarMultiplication(&vk.G1.Alpha, rhoSum.BigInt(new(big.Int)))
+	if _, err := kSum.MultiExp(kSums, rho, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	if _, err := krsSum.MultiExp(krs, rho, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	P = append(P, alpha, kSum, krsSum)
+	Q = append(Q, vk.G2.Beta, vk.G2.gammaNeg, vk.G2.deltaNeg)
+
+	ok, err := curve.PairingCheck(P, Q)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("batch pairing check failed")
+	}
+	return nil
+}
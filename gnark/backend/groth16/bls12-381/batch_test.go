@@ -0,0 +1,91 @@
+package groth16
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/backend/groth16/internal"
+	cs "github.com/consensys/gnark/constraint/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+// batchTasks returns valid tasks, with proofsPerKey[k] proofs for the k-th
+// verifying key of the same circuit.
+func batchTasks(tb testing.TB, proofsPerKey ...int) []Task {
+	ccs, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &unusedInputCircuit{}, frontend.IgnoreUnconstrainedInputs())
+	require.NoError(tb, err)
+	system := ccs.(*cs.R1CS)
+
+	var tasks []Task
+	for _, nbProofs := range proofsPerKey {
+		var (
+			pk ProvingKey
+			vk VerifyingKey
+		)
+		require.NoError(tb, Setup(system, &pk, &vk))
+		for i := 0; i < nbProofs; i++ {
+			x := i + 2
+			w, err := frontend.NewWitness(&unusedInputCircuit{X: x, Y: x * x, Unused: i}, ecc.BLS12_381.ScalarField())
+			require.NoError(tb, err)
+			proof, err := Prove(system, &pk, w)
+			require.NoError(tb, err)
+			publicWitness, err := w.Public()
+			require.NoError(tb, err)
+			tasks = append(tasks, Task{proof, &vk, publicWitness.Vector().(fr.Vector)})
+		}
+	}
+	return tasks
+}
+
+func TestVerifySmartBatch(t *testing.T) {
+	tasks := batchTasks(t, 4, 1, 3)
+
+	for _, err := range VerifySmartBatch(tasks) {
+		require.NoError(t, err)
+	}
+
+	// a copy of the verifying key is grouped with the original
+	vkCopy := *tasks[0].VerifyingKey
+	tasks[1].VerifyingKey = &vkCopy
+
+	// invalid proofs in a batched group and alone in a group
+	bad := *tasks[2].Proof
+	bad.Krs = tasks[3].Proof.Krs
+	tasks[2].Proof = &bad
+	tasks[4].PublicWitness = append(fr.Vector{}, tasks[4].PublicWitness...)
+	tasks[4].PublicWitness[0].SetUint64(1)
+	tasks[6].PublicWitness = tasks[6].PublicWitness[:1]
+
+	errs := VerifySmartBatch(tasks)
+	for i, err := range errs {
+		switch i {
+		case 2, 4:
+			require.ErrorIs(t, err, errPairingCheckFailed, "task %d", i)
+		case 6:
+			require.ErrorIs(t, err, internal.ErrInvalidWitnessSize, "task %d", i)
+		default:
+			require.NoError(t, err, "task %d", i)
+		}
+	}
+}
+
+func BenchmarkVerifySmartBatch(b *testing.B) {
+	// a realistic mix: most proofs for a popular circuit, a few others
+	tasks := batchTasks(b, 24, 6, 1, 1)
+
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, t := range tasks {
+				_ = Verify(t.Proof, t.VerifyingKey, t.PublicWitness)
+			}
+		}
+	})
+	b.Run("smart", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = VerifySmartBatch(tasks)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package groth16
+
+import (
+	"bytes"
+	"sort"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// Canonical returns a copy of the proof with the commitments sorted by their
+// compressed encoding, so that proofs differing only in the order of their
+// commitments serialize, and thus hash, to the same bytes.
+//
+// The order of the commitments is significant to the protocol: commitment i is
+// hashed with the public inputs of vk.PublicAndCommitmentCommitted[i] into the
+// i-th commitment wire, and the proof of knowledge is checked against the
+// commitments folded in order. Verify is therefore order-sensitive, and the
+// canonical form of a proof with several commitments is meant for equality and
+// deduplication only; it may not verify. Proofs with at most one commitment,
+// like arkworks proofs, are unchanged.
+func (proof *Proof) Canonical() *Proof {
+	res := *proof
+	res.Commitments = make([]curve.G1Affine, len(proof.Commitments))
+	copy(res.Commitments, proof.Commitments)
+
+	keys := make([][curve.SizeOfG1AffineCompressed]byte, len(res.Commitments))
+	for i := range res.Commitments {
+		keys[i] = res.Commitments[i].Bytes()
+	}
+	sort.Sort(byCompressedBytes{res.Commitments, keys})
+	return &res
+}
+
+type byCompressedBytes struct {
+	points []curve.G1Affine
+	keys   [][curve.SizeOfG1AffineCompressed]byte
+}
+
+func (s byCompressedBytes) Len() int { return len(s.points) }
+
+func (s byCompressedBytes) Less(i, j int) bool {
+	return bytes.Compare(s.keys[i][:], s.keys[j][:]) < 0
+}
+
+func (s byCompressedBytes) Swap(i, j int) {
+	s.points[i], s.points[j] = s.points[j], s.points[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
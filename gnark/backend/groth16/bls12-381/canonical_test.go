@@ -0,0 +1,32 @@
+package groth16
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofCanonical(t *testing.T) {
+	points, _ := randomTerms(t, 5)
+	proof := Proof{Ar: points[0], Krs: points[1], CommitmentPok: points[2]}
+	proof.Commitments = points[2:]
+
+	reordered := proof
+	reordered.Commitments = []curve.G1Affine{points[4], points[2], points[3]}
+
+	hash := func(p *Proof) []byte {
+		var buf bytes.Buffer
+		_, err := p.WriteRawTo(&buf)
+		require.NoError(t, err)
+		h := sha256.Sum256(buf.Bytes())
+		return h[:]
+	}
+	require.NotEqual(t, hash(&proof), hash(&reordered))
+	require.Equal(t, hash(proof.Canonical()), hash(reordered.Canonical()))
+
+	// the original proofs are left untouched
+	require.Equal(t, points[4], reordered.Commitments[0])
+}
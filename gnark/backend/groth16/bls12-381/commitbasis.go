@@ -0,0 +1,55 @@
+package groth16
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/pedersen"
+	"github.com/consensys/gnark/backend/groth16/internal"
+)
+
+var (
+	errCommitmentBasisCurve = errors.New("commitment basis is on another curve than the verifying key")
+	errNoCommitments        = errors.New("verifying key has no commitments")
+
+	errCommitmentBasisMismatch = internal.ErrCommitmentBasisMismatch
+)
+
+// CommitmentBasis is a Pedersen verifying basis for the commitments of a
+// proof, configured apart from the verifying key produced by Setup.
+//
+// Curve is the curve of the basis. It must be BLS12-381: the commitments are
+// added to the public input point paired with γ, and their proof of knowledge
+// is checked with the pairing e(D, G)·e(PoK, G^{-1/σ}) = 1, so the commitment
+// points can't live on a companion curve. Schemes committing on a second
+// group must bring their commitments back to this curve before verifying.
+//
+// Key holds the generators G and G^{-1/σ} on G2. Any pair the prover's
+// commitment key agrees with is accepted, such as one from a separate
+// ceremony, or one scaled by a common factor.
+type CommitmentBasis struct {
+	Curve ecc.ID
+	Key   pedersen.VerifyingKey
+}
+
+// WithCommitmentBasis returns a copy of vk checking the commitments of proofs
+// against basis instead of vk.CommitmentKey. Proofs failing the check against
+// it are reported with ErrCommitmentBasisMismatch. The basis is serialized
+// with the returned key in place of the original one; once read back, it is
+// checked as the key's own commitment key.
+func (vk *VerifyingKey) WithCommitmentBasis(basis CommitmentBasis) (*VerifyingKey, error) {
+	if basis.Curve != vk.CurveID() {
+		return nil, fmt.Errorf("%w: got %s, expected %s", errCommitmentBasisCurve, basis.Curve, vk.CurveID())
+	}
+	if len(vk.PublicAndCommitmentCommitted) == 0 {
+		return nil, errNoCommitments
+	}
+	if basis.Key.G.IsInfinity() || !basis.Key.G.IsInSubGroup() || !basis.Key.GRootSigmaNeg.IsInSubGroup() {
+		return nil, fmt.Errorf("commitment basis: %w", errCorrectSubgroupCheckFailed)
+	}
+	res := *vk
+	res.CommitmentKey = basis.Key
+	res.commitmentBasis = true
+	return &res, nil
+}
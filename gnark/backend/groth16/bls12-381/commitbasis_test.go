@@ -0,0 +1,81 @@
+package groth16
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	cs "github.com/consensys/gnark/constraint/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+type committedCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *committedCircuit) Define(api frontend.API) error {
+	commitment, err := api.(frontend.Committer).Commit(c.X, c.Y)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(commitment, 0)
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+func TestVerifyCommitmentBasis(t *testing.T) {
+	ccs, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &committedCircuit{})
+	require.NoError(t, err)
+	var (
+		pk ProvingKey
+		vk VerifyingKey
+	)
+	require.NoError(t, Setup(ccs.(*cs.R1CS), &pk, &vk))
+	w, err := frontend.NewWitness(&committedCircuit{X: 3, Y: 9}, ecc.BLS12_381.ScalarField())
+	require.NoError(t, err)
+	proof, err := Prove(ccs.(*cs.R1CS), &pk, w)
+	require.NoError(t, err)
+	publicWitness, err := w.Public()
+	require.NoError(t, err)
+	public := publicWitness.Vector().(fr.Vector)
+
+	// both generators scaled by s: e(D, s·G)·e(PoK, s·G^{-1/σ}) = 1 still holds
+	s := big.NewInt(5)
+	basis := CommitmentBasis{Curve: ecc.BLS12_381, Key: vk.CommitmentKey}
+	basis.Key.G.ScalarMultiplication(&basis.Key.G, s)
+	basis.Key.GRootSigmaNeg.ScalarMultiplication(&basis.Key.GRootSigmaNeg, s)
+	require.NotEqual(t, vk.CommitmentKey, basis.Key)
+	distinct, err := vk.WithCommitmentBasis(basis)
+	require.NoError(t, err)
+	require.NoError(t, Verify(proof, distinct, public))
+	require.Equal(t, basis.Key, distinct.CommitmentKey)
+	require.NotEqual(t, basis.Key, vk.CommitmentKey, "vk is left as is")
+
+	// G alone scaled
+	mismatched := CommitmentBasis{Curve: ecc.BLS12_381, Key: vk.CommitmentKey}
+	mismatched.Key.G.ScalarMultiplication(&mismatched.Key.G, s)
+	other, err := vk.WithCommitmentBasis(mismatched)
+	require.NoError(t, err)
+	require.ErrorIs(t, Verify(proof, other, public), errCommitmentBasisMismatch)
+
+	// without a basis, a failing proof of knowledge is a plain commitment error
+	tampered := *proof
+	tampered.CommitmentPok.Double(&tampered.CommitmentPok)
+	err = Verify(&tampered, &vk, public)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, errCommitmentBasisMismatch)
+	require.ErrorIs(t, Verify(&tampered, distinct, public), errCommitmentBasisMismatch)
+
+	_, err = vk.WithCommitmentBasis(CommitmentBasis{Curve: ecc.BN254, Key: basis.Key})
+	require.ErrorIs(t, err, errCommitmentBasisCurve)
+	_, err = vk.WithCommitmentBasis(CommitmentBasis{Curve: ecc.BLS12_381})
+	require.ErrorIs(t, err, errCorrectSubgroupCheckFailed)
+
+	_, vkNoCommitment, _ := proofFixture(t)
+	_, err = vkNoCommitment.WithCommitmentBasis(basis)
+	require.ErrorIs(t, err, errNoCommitments)
+}
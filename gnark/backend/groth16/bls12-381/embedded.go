@@ -0,0 +1,133 @@
+package groth16
+
+import (
+	"errors"
+	"fmt"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+)
+
+// SizeOfG2AffineEmbedded is the size of an uncompressed G2 point whose
+// coordinates are serialized as arkworks Fq12 elements.
+const SizeOfG2AffineEmbedded = 2 * 12 * fp.Bytes
+
+// ParseG2 decodes a G2 point, telling its representation by its size:
+//
+//   - SizeOfG2AffineCompressed or SizeOfG2AffineUncompressed bytes: the
+//     twist coordinates over Fp2, as written by arkworks and gnark-crypto;
+//   - SizeOfG2AffineEmbedded bytes: the point mapped into E(Fp12), as written
+//     by generic pairing code serializing the untwisted point.
+//
+// In the embedded form, each coordinate is an arkworks Fq12 element
+// c0 + c1·w, with Fq6 = Fq2[v]/(v³ - ξ), Fq12 = Fq6[w]/(w² - v) and ξ = 1+u;
+// every Fq2 element is c0 then c1, little-endian, and the flags are the most
+// significant bits of the last byte, as in ReadG1WithFlags. The untwist map of
+// the M-twist of BLS12-381 is (x', y') ↦ (x'·w⁻², y'·w⁻³) = (x'/ξ·v², y'/ξ·v·w),
+// so the point must only have those two components, from which the twist
+// coordinates are recovered.
+func ParseG2(b []byte) (curve.G2Affine, error) {
+	var p curve.G2Affine
+	switch len(b) {
+	case curve.SizeOfG2AffineCompressed, curve.SizeOfG2AffineUncompressed:
+		_, err := p.SetBytes(b)
+		return p, err
+	case SizeOfG2AffineEmbedded:
+		return parseG2Embedded(b)
+	default:
+		return p, fmt.Errorf("invalid G2 point size %d", len(b))
+	}
+}
+
+func parseG2Embedded(b []byte) (curve.G2Affine, error) {
+	var p curve.G2Affine
+	buf := make([]byte, len(b))
+	copy(buf, b)
+	last := &buf[len(buf)-1]
+	flags := *last >> (8 - flagBits)
+	*last &= 0xff >> flagBits
+
+	var x, y curve.E12
+	for i, e := range []*curve.E12{&x, &y} {
+		if err := setE12LittleEndian(e, buf[i*SizeOfG2AffineEmbedded/2:(i+1)*SizeOfG2AffineEmbedded/2]); err != nil {
+			return p, err
+		}
+	}
+	if flags&0b010 != 0 {
+		if !x.IsZero() || !y.IsZero() {
+			return p, errors.New("invalid encoding of the point at infinity")
+		}
+		return p, nil
+	}
+	if flags != 0 {
+		return p, errors.New("unexpected flags")
+	}
+
+	// x = x'/ξ·v², y = y'/ξ·v·w
+	expectedX := curve.E12{C0: curve.E6{B2: x.C0.B2}}
+	expectedY := curve.E12{C1: curve.E6{B1: y.C1.B1}}
+	if !x.Equal(&expectedX) || !y.Equal(&expectedY) {
+		return p, errors.New("point is not in the image of the twist")
+	}
+	p.X.MulByNonResidue(&x.C0.B2)
+	p.Y.MulByNonResidue(&y.C1.B1)
+	if !p.IsInSubGroup() {
+		return p, errors.New("invalid point: subgroup check failed")
+	}
+	return p, nil
+}
+
+// setE12LittleEndian sets z to the arkworks serialization b of an Fq12
+// element.
+func setE12LittleEndian(z *curve.E12, b []byte) error {
+	coeffs := []*fp.Element{
+		&z.C0.B0.A0, &z.C0.B0.A1, &z.C0.B1.A0, &z.C0.B1.A1, &z.C0.B2.A0, &z.C0.B2.A1,
+		&z.C1.B0.A0, &z.C1.B0.A1, &z.C1.B1.A0, &z.C1.B1.A1, &z.C1.B2.A0, &z.C1.B2.A1,
+	}
+	for i, c := range coeffs {
+		if err := setFpLittleEndian(c, b[i*fp.Bytes:(i+1)*fp.Bytes]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseProof decodes an arkworks proof Ar | Bs | Krs whose points may each be
+// compressed or not, and Bs embedded (see ParseG2). The representation of Bs
+// follows from the size of the proof and of Ar, every combination giving a
+// different size.
+func ParseProof(data []byte) (*Proof, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty proof")
+	}
+	g1Size := func(first byte) int {
+		if first&compressedFlag != 0 {
+			return curve.SizeOfG1AffineCompressed
+		}
+		return curve.SizeOfG1AffineUncompressed
+	}
+
+	proof := new(Proof)
+	arSize := g1Size(data[0])
+	if len(data) < arSize {
+		return nil, errors.New("Ar: truncated")
+	}
+	if _, err := proof.Ar.SetBytes(data[:arSize]); err != nil {
+		return nil, fmt.Errorf("Ar: %w", err)
+	}
+	rest := data[arSize:]
+	for _, bsSize := range []int{curve.SizeOfG2AffineCompressed, curve.SizeOfG2AffineUncompressed, SizeOfG2AffineEmbedded} {
+		if len(rest) <= bsSize || len(rest)-bsSize != g1Size(rest[bsSize]) {
+			continue
+		}
+		var err error
+		if proof.Bs, err = ParseG2(rest[:bsSize]); err != nil {
+			return nil, fmt.Errorf("Bs: %w", err)
+		}
+		if _, err = proof.Krs.SetBytes(rest[bsSize:]); err != nil {
+			return nil, fmt.Errorf("Krs: %w", err)
+		}
+		return proof, nil
+	}
+	return nil, fmt.Errorf("invalid proof size %d", len(data))
+}
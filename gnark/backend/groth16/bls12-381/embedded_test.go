@@ -0,0 +1,77 @@
+package groth16
+
+import (
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/stretchr/testify/require"
+)
+
+// embedG2 serializes the image of p in E(Fp12) the way arkworks serializes
+// an uncompressed point with Fq12 coordinates.
+func embedG2(p *curve.G2Affine) []byte {
+	var x, y curve.E12
+	x.C0.B2.MulByNonResidueInv(&p.X)
+	y.C1.B1.MulByNonResidueInv(&p.Y)
+
+	var res []byte
+	for _, e := range []*curve.E12{&x, &y} {
+		for _, c := range []*fp.Element{
+			&e.C0.B0.A0, &e.C0.B0.A1, &e.C0.B1.A0, &e.C0.B1.A1, &e.C0.B2.A0, &e.C0.B2.A1,
+			&e.C1.B0.A0, &e.C1.B0.A1, &e.C1.B1.A0, &e.C1.B1.A1, &e.C1.B2.A0, &e.C1.B2.A1,
+		} {
+			res = append(res, fpLittleEndian(c)...)
+		}
+	}
+	return res
+}
+
+func TestParseG2Embedded(t *testing.T) {
+	proof, vk, publicWitness := proofFixture(t)
+
+	// the embedded point is on E: y² = x³ + 4 over Fp12
+	var x, y, lhs, rhs, four curve.E12
+	embedded := embedG2(&proof.Bs)
+	require.Len(t, embedded, SizeOfG2AffineEmbedded)
+	require.NoError(t, setE12LittleEndian(&x, embedded[:SizeOfG2AffineEmbedded/2]))
+	require.NoError(t, setE12LittleEndian(&y, embedded[SizeOfG2AffineEmbedded/2:]))
+	four.C0.B0.A0.SetUint64(4)
+	lhs.Square(&y)
+	rhs.Square(&x).Mul(&rhs, &x).Add(&rhs, &four)
+	require.True(t, lhs.Equal(&rhs))
+
+	bs, err := ParseG2(embedded)
+	require.NoError(t, err)
+	require.True(t, bs.Equal(&proof.Bs))
+
+	// proof with an embedded Bs, Ar uncompressed and Krs compressed
+	ar, krs := proof.Ar.RawBytes(), proof.Krs.Bytes()
+	data := append(append(ar[:], embedded...), krs[:]...)
+	parsed, err := ParseProof(data)
+	require.NoError(t, err)
+	require.NoError(t, Verify(parsed, vk, publicWitness))
+
+	// and the usual representations
+	parsed, err = ParseProof(arkworksProofBytes(proof))
+	require.NoError(t, err)
+	require.NoError(t, Verify(parsed, vk, publicWitness))
+
+	// a point with other Fp12 components is not from the twist
+	bad := append([]byte(nil), embedded...)
+	bad[0] ^= 1
+	_, err = ParseG2(bad)
+	require.Error(t, err)
+
+	// infinity
+	inf := make([]byte, SizeOfG2AffineEmbedded)
+	inf[len(inf)-1] = 0b010 << (8 - flagBits)
+	bs, err = ParseG2(inf)
+	require.NoError(t, err)
+	require.True(t, bs.IsInfinity())
+
+	_, err = ParseG2(embedded[1:])
+	require.Error(t, err)
+	_, err = ParseProof(data[1:])
+	require.Error(t, err)
+}
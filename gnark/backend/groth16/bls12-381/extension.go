@@ -0,0 +1,66 @@
+package groth16
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Fp2 is an element C0 + C1·u of a quadratic extension of fr, as exposed by
+// circuits working over extension fields. It is laid out as public inputs in
+// (C0, C1) order, the order of arkworks QuadExtField and of gnark's E2.
+type Fp2 struct {
+	C0, C1 fr.Element
+}
+
+// Fp4 is an element C0 + C1·v of a quadratic extension of Fp2. It is laid out
+// as public inputs in (C0.C0, C0.C1, C1.C0, C1.C1) order.
+type Fp4 struct {
+	C0, C1 Fp2
+}
+
+// PackFp2 flattens elements into consecutive public inputs.
+func PackFp2(elements ...Fp2) fr.Vector {
+	res := make(fr.Vector, 0, 2*len(elements))
+	for i := range elements {
+		res = append(res, elements[i].C0, elements[i].C1)
+	}
+	return res
+}
+
+// UnpackFp2 reads len(inputs)/2 elements packed by PackFp2.
+func UnpackFp2(inputs []fr.Element) ([]Fp2, error) {
+	if len(inputs)%2 != 0 {
+		return nil, fmt.Errorf("expected a multiple of 2 inputs, got %d", len(inputs))
+	}
+	res := make([]Fp2, len(inputs)/2)
+	for i := range res {
+		res[i].C0, res[i].C1 = inputs[2*i], inputs[2*i+1]
+	}
+	return res, nil
+}
+
+// PackFp4 flattens elements into consecutive public inputs.
+func PackFp4(elements ...Fp4) fr.Vector {
+	res := make(fr.Vector, 0, 4*len(elements))
+	for i := range elements {
+		res = append(res, PackFp2(elements[i].C0, elements[i].C1)...)
+	}
+	return res
+}
+
+// UnpackFp4 reads len(inputs)/4 elements packed by PackFp4.
+func UnpackFp4(inputs []fr.Element) ([]Fp4, error) {
+	if len(inputs)%4 != 0 {
+		return nil, fmt.Errorf("expected a multiple of 4 inputs, got %d", len(inputs))
+	}
+	fp2, err := UnpackFp2(inputs)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Fp4, len(inputs)/4)
+	for i := range res {
+		res[i].C0, res[i].C1 = fp2[2*i], fp2[2*i+1]
+	}
+	return res, nil
+}
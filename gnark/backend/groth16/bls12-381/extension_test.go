@@ -0,0 +1,88 @@
+package groth16_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/assert"
+)
+
+// fp2NonResidue defines Fp2 = fr[u]/(u² - 7) in the test circuit.
+const fp2NonResidue = 7
+
+type fp2Variable struct {
+	C0, C1 frontend.Variable
+}
+
+// fp2MulCircuit exposes the product of two secret Fp2 elements.
+type fp2MulCircuit struct {
+	X, Y fp2Variable
+	Z    fp2Variable `gnark:",public"`
+}
+
+func (c *fp2MulCircuit) Define(api frontend.API) error {
+	c0 := api.Add(api.Mul(c.X.C0, c.Y.C0), api.Mul(fp2NonResidue, c.X.C1, c.Y.C1))
+	c1 := api.Add(api.Mul(c.X.C0, c.Y.C1), api.Mul(c.X.C1, c.Y.C0))
+	api.AssertIsEqual(c0, c.Z.C0)
+	api.AssertIsEqual(c1, c.Z.C1)
+	return nil
+}
+
+func fp2Mul(x, y groth16_bls12381.Fp2) groth16_bls12381.Fp2 {
+	var z groth16_bls12381.Fp2
+	var t, nr fr.Element
+	nr.SetUint64(fp2NonResidue)
+	z.C0.Mul(&x.C0, &y.C0)
+	t.Mul(&x.C1, &y.C1).Mul(&t, &nr)
+	z.C0.Add(&z.C0, &t)
+	z.C1.Mul(&x.C0, &y.C1)
+	t.Mul(&x.C1, &y.C0)
+	z.C1.Add(&z.C1, &t)
+	return z
+}
+
+func randomFp2() groth16_bls12381.Fp2 {
+	var e groth16_bls12381.Fp2
+	e.C0.SetRandom()
+	e.C1.SetRandom()
+	return e
+}
+
+func TestPackFp2(t *testing.T) {
+	a, b := randomFp2(), randomFp2()
+	packed := groth16_bls12381.PackFp2(a, b)
+	assert.Equal(t, fr.Vector{a.C0, a.C1, b.C0, b.C1}, packed)
+	unpacked, err := groth16_bls12381.UnpackFp2(packed)
+	assert.NoError(t, err)
+	assert.Equal(t, []groth16_bls12381.Fp2{a, b}, unpacked)
+	_, err = groth16_bls12381.UnpackFp2(packed[:3])
+	assert.Error(t, err)
+
+	c := groth16_bls12381.Fp4{C0: a, C1: b}
+	assert.Equal(t, packed, groth16_bls12381.PackFp4(c))
+	unpacked4, err := groth16_bls12381.UnpackFp4(packed)
+	assert.NoError(t, err)
+	assert.Equal(t, []groth16_bls12381.Fp4{c}, unpacked4)
+	_, err = groth16_bls12381.UnpackFp4(packed[:2])
+	assert.Error(t, err)
+}
+
+func TestVerifyFp2PublicInput(t *testing.T) {
+	x, y := randomFp2(), randomFp2()
+	z := fp2Mul(x, y)
+
+	ccs, pk, vk := setup(t, &fp2MulCircuit{})
+	_, proof := prove(t, &fp2MulCircuit{
+		X: fp2Variable{x.C0, x.C1},
+		Y: fp2Variable{y.C0, y.C1},
+		Z: fp2Variable{z.C0, z.C1},
+	}, ccs, pk)
+
+	p, v := proof.(*groth16_bls12381.Proof), vk.(*groth16_bls12381.VerifyingKey)
+	assert.NoError(t, groth16_bls12381.Verify(p, v, groth16_bls12381.PackFp2(z)))
+
+	// the components in (C1, C0) order must not verify
+	assert.Error(t, groth16_bls12381.Verify(p, v, fr.Vector{z.C1, z.C0}))
+}
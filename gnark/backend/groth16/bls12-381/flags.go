@@ -0,0 +1,62 @@
+package groth16
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+)
+
+// flagBits is the number of unused most significant bits of a serialized fp
+// element, which arkworks uses to store flags.
+const flagBits = 8*fp.Bytes - fp.Bits
+
+// ReadG1WithFlags reads an uncompressed G1 point the way arkworks
+// CanonicalDeserializeWithFlags does with the generic short Weierstrass
+// layout: x then y, each fp.Bytes little-endian, with flags stored in the
+// flagBits unused most significant bits of the last byte of y.
+//
+// The point is decoded from its coordinates only and checked to be on the
+// curve and in the subgroup; the flag bits are returned as is, shifted down.
+// With the standard arkworks SWFlags, bit 1 marks the point at infinity (whose
+// coordinates are zero) and bit 0 is unused in uncompressed form, so custom
+// formats can stash metadata there.
+func ReadG1WithFlags(r io.Reader) (curve.G1Affine, uint8, error) {
+	var (
+		p   curve.G1Affine
+		buf [2 * fp.Bytes]byte
+	)
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return p, 0, err
+	}
+	last := &buf[len(buf)-1]
+	flags := *last >> (8 - flagBits)
+	*last &= 0xff >> flagBits
+
+	for i, dst := range []*fp.Element{&p.X, &p.Y} {
+		if err := setFpLittleEndian(dst, buf[i*fp.Bytes:(i+1)*fp.Bytes]); err != nil {
+			return p, 0, err
+		}
+	}
+	if !p.IsInSubGroup() {
+		return p, 0, errors.New("point is not on the curve or not in the subgroup")
+	}
+	return p, flags, nil
+}
+
+// setFpLittleEndian sets z to the little-endian integer b, which must be
+// reduced modulo p.
+func setFpLittleEndian(z *fp.Element, b []byte) error {
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	v := new(big.Int).SetBytes(be)
+	if v.Cmp(fp.Modulus()) >= 0 {
+		return errors.New("coordinate is not reduced modulo p")
+	}
+	z.SetBigInt(v)
+	return nil
+}
@@ -0,0 +1,53 @@
+package groth16
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/stretchr/testify/require"
+)
+
+// writeG1WithFlags serializes p as arkworks CanonicalSerializeWithFlags does.
+func writeG1WithFlags(p *curve.G1Affine, flags uint8) []byte {
+	var buf bytes.Buffer
+	for _, c := range []*fp.Element{&p.X, &p.Y} {
+		b := c.BigInt(new(big.Int)).FillBytes(make([]byte, fp.Bytes))
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+		buf.Write(b)
+	}
+	res := buf.Bytes()
+	res[len(res)-1] |= flags << (8 - flagBits)
+	return res
+}
+
+func TestReadG1WithFlags(t *testing.T) {
+	points, _ := randomTerms(t, 1)
+
+	for _, flags := range []uint8{0b000, 0b001, 0b101} {
+		r := bytes.NewReader(writeG1WithFlags(&points[0], flags))
+		p, f, err := ReadG1WithFlags(r)
+		require.NoError(t, err)
+		require.Equal(t, flags, f)
+		require.True(t, p.Equal(&points[0]))
+		require.Zero(t, r.Len())
+	}
+
+	// point at infinity with the SWFlags infinity bit
+	p, f, err := ReadG1WithFlags(bytes.NewReader(writeG1WithFlags(&curve.G1Affine{}, 0b010)))
+	require.NoError(t, err)
+	require.Equal(t, uint8(0b010), f)
+	require.True(t, p.IsInfinity())
+
+	bad := points[0]
+	bad.Y.Double(&bad.Y)
+	_, _, err = ReadG1WithFlags(bytes.NewReader(writeG1WithFlags(&bad, 0)))
+	require.Error(t, err)
+
+	_, _, err = ReadG1WithFlags(bytes.NewReader(writeG1WithFlags(&points[0], 0)[:2*fp.Bytes-1]))
+	require.Error(t, err)
+}
@@ -0,0 +1,324 @@
+package groth16
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+)
+
+// FlagScheme selects how the flags of a compressed point are encoded.
+type FlagScheme uint8
+
+const (
+	// FlagScheme3Bit is the zcash encoding used by current versions of
+	// ark-bls12-381: coordinates are big-endian and the three most significant
+	// bits of the first byte flag compression, infinity and the sign of y.
+	FlagScheme3Bit FlagScheme = iota
+
+	// FlagScheme2Bit is the generic short Weierstrass encoding (SWFlags) older
+	// arkworks versions used for all curves: coordinates are little-endian and
+	// the two most significant bits of the last byte flag a negative y (bit 7)
+	// and the point at infinity (bit 6). Compression is implied by the size, y
+	// is negative when it's lexicographically larger than -y, and an Fp2
+	// coordinate is compared on c1 first.
+	FlagScheme2Bit
+)
+
+const (
+	swFlagNegative = 0b1000_0000
+	swFlagInfinity = 0b0100_0000
+	swFlagsMask    = swFlagNegative | swFlagInfinity
+)
+
+func (s FlagScheme) String() string {
+	switch s {
+	case FlagScheme3Bit:
+		return "3bit"
+	case FlagScheme2Bit:
+		return "2bit"
+	default:
+		return fmt.Sprintf("FlagScheme(%d)", s)
+	}
+}
+
+// CoordForm is the representation of serialized point coordinates.
+type CoordForm uint8
+
+const (
+	// CoordCanonical coordinates are serialized as the integer they represent.
+	CoordCanonical CoordForm = iota
+	// CoordMontgomery coordinates are serialized as x·R mod p, R = 2^384, the
+	// internal representation of arkworks and gnark-crypto, as dumped by
+	// serializers copying the limbs of the field elements.
+	CoordMontgomery
+)
+
+func (f CoordForm) String() string {
+	switch f {
+	case CoordCanonical:
+		return "canonical"
+	case CoordMontgomery:
+		return "montgomery"
+	default:
+		return fmt.Sprintf("CoordForm(%d)", f)
+	}
+}
+
+// ReadOption configures how ReadCompressedProof and ReadCompressedVerifyingKey
+// decode points.
+type ReadOption func(*readConfig) error
+
+type readConfig struct {
+	flagScheme FlagScheme
+	coordForm  CoordForm
+}
+
+// WithFlagScheme sets the flag scheme of compressed points. The default is
+// FlagScheme3Bit.
+func WithFlagScheme(s FlagScheme) ReadOption {
+	return func(cfg *readConfig) error {
+		if s != FlagScheme3Bit && s != FlagScheme2Bit {
+			return fmt.Errorf("unknown flag scheme %s", s)
+		}
+		cfg.flagScheme = s
+		return nil
+	}
+}
+
+// WithCoordForm sets the representation of the point coordinates. The default
+// is CoordCanonical; Montgomery-form coordinates are valid field elements too,
+// but decode to the wrong points if read as canonical.
+func WithCoordForm(f CoordForm) ReadOption {
+	return func(cfg *readConfig) error {
+		if f != CoordCanonical && f != CoordMontgomery {
+			return fmt.Errorf("unknown coordinate form %s", f)
+		}
+		cfg.coordForm = f
+		return nil
+	}
+}
+
+func newReadConfig(opts []ReadOption) (readConfig, error) {
+	var cfg readConfig
+	for _, o := range opts {
+		if err := o(&cfg); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
+
+// ReadCompressedProof reads an arkworks proof serialized with compressed
+// points: Ar, Bs then Krs. Points are checked to be on the curve and in the
+// subgroup.
+func ReadCompressedProof(r io.Reader, opts ...ReadOption) (*Proof, error) {
+	cfg, err := newReadConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	proof := new(Proof)
+	if proof.Ar, err = cfg.readG1(r); err != nil {
+		return nil, fmt.Errorf("Ar: %w", err)
+	}
+	if proof.Bs, err = cfg.readG2(r); err != nil {
+		return nil, fmt.Errorf("Bs: %w", err)
+	}
+	if proof.Krs, err = cfg.readG1(r); err != nil {
+		return nil, fmt.Errorf("Krs: %w", err)
+	}
+	return proof, nil
+}
+
+// ReadCompressedVerifyingKey reads an arkworks verifying key serialized with
+// compressed points: alpha_g1, beta_g2, gamma_g2, delta_g2, then the number of
+// gamma_abc_g1 entries as a little-endian uint64 followed by the entries.
+func ReadCompressedVerifyingKey(r io.Reader, opts ...ReadOption) (*VerifyingKey, error) {
+	cfg, err := newReadConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	vk := new(VerifyingKey)
+	if vk.G1.Alpha, err = cfg.readG1(r); err != nil {
+		return nil, fmt.Errorf("alpha_g1: %w", err)
+	}
+	for _, e := range []struct {
+		name string
+		p    *curve.G2Affine
+	}{{"beta_g2", &vk.G2.Beta}, {"gamma_g2", &vk.G2.Gamma}, {"delta_g2", &vk.G2.Delta}} {
+		if *e.p, err = cfg.readG2(r); err != nil {
+			return nil, fmt.Errorf("%s: %w", e.name, err)
+		}
+	}
+
+	var n uint64
+	if err = binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return nil, fmt.Errorf("gamma_abc_g1 length: %w", err)
+	}
+	if n == 0 || n > 1<<32 {
+		return nil, fmt.Errorf("invalid gamma_abc_g1 length %d", n)
+	}
+	for i := uint64(0); i < n; i++ {
+		p, err := cfg.readG1(r)
+		if err != nil {
+			return nil, fmt.Errorf("gamma_abc_g1[%d]: %w", i, err)
+		}
+		vk.G1.K = append(vk.G1.K, p)
+	}
+	vk.PublicAndCommitmentCommitted = [][]int{}
+
+	if err = vk.Precompute(); err != nil {
+		return nil, err
+	}
+	return vk, nil
+}
+
+func (cfg *readConfig) readG1(r io.Reader) (curve.G1Affine, error) {
+	var (
+		p   curve.G1Affine
+		buf [curve.SizeOfG1AffineCompressed]byte
+	)
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return p, err
+	}
+	if cfg.flagScheme == FlagScheme3Bit {
+		if err := cfg.canonicalZcash(buf[:]); err != nil {
+			return p, err
+		}
+		_, err := p.SetBytes(buf[:])
+		return p, err
+	}
+
+	flags := buf[len(buf)-1] & swFlagsMask
+	buf[len(buf)-1] &^= swFlagsMask
+	if err := setFpLittleEndian(&p.X, buf[:]); err != nil {
+		return p, err
+	}
+	cfg.canonical(&p.X)
+	if flags&swFlagInfinity != 0 {
+		if flags != swFlagInfinity || !p.X.IsZero() {
+			return p, errors.New("invalid encoding of the point at infinity")
+		}
+		return curve.G1Affine{}, nil
+	}
+
+	// y² = x³ + 4
+	var ySquared, b fp.Element
+	b.SetUint64(4)
+	ySquared.Square(&p.X).Mul(&ySquared, &p.X).Add(&ySquared, &b)
+	if p.Y.Sqrt(&ySquared) == nil {
+		return p, errors.New("invalid compressed coordinate: square root doesn't exist")
+	}
+	if p.Y.LexicographicallyLargest() != (flags&swFlagNegative != 0) {
+		p.Y.Neg(&p.Y)
+	}
+	if !p.IsInSubGroup() {
+		return p, errors.New("invalid point: subgroup check failed")
+	}
+	return p, nil
+}
+
+func (cfg *readConfig) readG2(r io.Reader) (curve.G2Affine, error) {
+	var (
+		p   curve.G2Affine
+		buf [curve.SizeOfG2AffineCompressed]byte
+	)
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return p, err
+	}
+	if cfg.flagScheme == FlagScheme3Bit {
+		if err := cfg.canonicalZcash(buf[:]); err != nil {
+			return p, err
+		}
+		_, err := p.SetBytes(buf[:])
+		return p, err
+	}
+
+	flags := buf[len(buf)-1] & swFlagsMask
+	buf[len(buf)-1] &^= swFlagsMask
+	if err := setFpLittleEndian(&p.X.A0, buf[:fp.Bytes]); err != nil {
+		return p, err
+	}
+	if err := setFpLittleEndian(&p.X.A1, buf[fp.Bytes:]); err != nil {
+		return p, err
+	}
+	cfg.canonical(&p.X.A0)
+	cfg.canonical(&p.X.A1)
+	if flags&swFlagInfinity != 0 {
+		if flags != swFlagInfinity || !p.X.IsZero() {
+			return p, errors.New("invalid encoding of the point at infinity")
+		}
+		return curve.G2Affine{}, nil
+	}
+
+	// y² = x³ + 4(1+u)
+	var ySquared, b, check curve.E2
+	b.A0.SetUint64(4)
+	b.A1.SetUint64(4)
+	ySquared.Square(&p.X).Mul(&ySquared, &p.X).Add(&ySquared, &b)
+	if ySquared.Legendre() == -1 {
+		return p, errors.New("invalid compressed coordinate: square root doesn't exist")
+	}
+	p.Y.Sqrt(&ySquared)
+	if !check.Square(&p.Y).Equal(&ySquared) {
+		return p, errors.New("invalid compressed coordinate: square root doesn't exist")
+	}
+	if e2LexicographicallyLargest(&p.Y) != (flags&swFlagNegative != 0) {
+		p.Y.Neg(&p.Y)
+	}
+	if !p.IsInSubGroup() {
+		return p, errors.New("invalid point: subgroup check failed")
+	}
+	return p, nil
+}
+
+// canonical converts z, read as serialized, to the coordinate it represents.
+func (cfg *readConfig) canonical(z *fp.Element) {
+	if cfg.coordForm == CoordMontgomery {
+		z.Mul(z, &montgomeryRInv)
+	}
+}
+
+// canonicalZcash rewrites in place the big-endian coordinates of a zcash
+// encoded point in canonical form, keeping the flags.
+func (cfg *readConfig) canonicalZcash(b []byte) error {
+	if cfg.coordForm == CoordCanonical {
+		return nil
+	}
+	flags := b[0] &^ (0xff >> flagBits)
+	b[0] &^= flags
+	var z fp.Element
+	for i := 0; i < len(b); i += fp.Bytes {
+		v := new(big.Int).SetBytes(b[i : i+fp.Bytes])
+		if v.Cmp(fp.Modulus()) >= 0 {
+			return errors.New("coordinate is not reduced modulo p")
+		}
+		z.SetBigInt(v)
+		cfg.canonical(&z)
+		z.BigInt(v).FillBytes(b[i : i+fp.Bytes])
+	}
+	b[0] |= flags
+	return nil
+}
+
+// montgomeryRInv is R⁻¹ mod p, R = 2^384.
+var montgomeryRInv = func() fp.Element {
+	rInv := new(big.Int).Lsh(big.NewInt(1), 8*fp.Bytes)
+	rInv.ModInverse(rInv, fp.Modulus())
+	var z fp.Element
+	z.SetBigInt(rInv)
+	return z
+}()
+
+// e2LexicographicallyLargest reports whether y > -y, comparing c1 first as
+// arkworks orders quadratic extension elements.
+func e2LexicographicallyLargest(y *curve.E2) bool {
+	if y.A1.IsZero() {
+		return y.A0.LexicographicallyLargest()
+	}
+	return y.A1.LexicographicallyLargest()
+}
@@ -0,0 +1,206 @@
+package groth16
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/stretchr/testify/require"
+)
+
+func fpLittleEndian(e *fp.Element) []byte {
+	b := e.BigInt(new(big.Int)).FillBytes(make([]byte, fp.Bytes))
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
+
+// compressG1TwoBit serializes p as older arkworks versions did with SWFlags.
+func compressG1TwoBit(p *curve.G1Affine) []byte {
+	if p.IsInfinity() {
+		b := make([]byte, fp.Bytes)
+		b[len(b)-1] = swFlagInfinity
+		return b
+	}
+	b := fpLittleEndian(&p.X)
+	if p.Y.LexicographicallyLargest() {
+		b[len(b)-1] |= swFlagNegative
+	}
+	return b
+}
+
+func compressG2TwoBit(p *curve.G2Affine) []byte {
+	b := append(fpLittleEndian(&p.X.A0), fpLittleEndian(&p.X.A1)...)
+	if p.IsInfinity() {
+		b[len(b)-1] = swFlagInfinity
+	} else if e2LexicographicallyLargest(&p.Y) {
+		b[len(b)-1] |= swFlagNegative
+	}
+	return b
+}
+
+func TestFlagSchemes(t *testing.T) {
+	_, _, g1, g2 := curve.Generators()
+	var p1, n1 curve.G1Affine
+	var p2, n2 curve.G2Affine
+	p1.ScalarMultiplication(&g1, big.NewInt(12345))
+	n1.Neg(&p1)
+	p2.ScalarMultiplication(&g2, big.NewInt(12345))
+	n2.Neg(&p2)
+
+	twoBit := readConfig{flagScheme: FlagScheme2Bit}
+	threeBit := readConfig{flagScheme: FlagScheme3Bit}
+
+	for _, p := range []*curve.G1Affine{&p1, &n1, {}} {
+		zcash := p.Bytes()
+		read, err := threeBit.readG1(bytes.NewReader(zcash[:]))
+		require.NoError(t, err)
+		require.True(t, read.Equal(p))
+
+		read, err = twoBit.readG1(bytes.NewReader(compressG1TwoBit(p)))
+		require.NoError(t, err)
+		require.True(t, read.Equal(p))
+	}
+	for _, p := range []*curve.G2Affine{&p2, &n2, {}} {
+		zcash := p.Bytes()
+		read, err := threeBit.readG2(bytes.NewReader(zcash[:]))
+		require.NoError(t, err)
+		require.True(t, read.Equal(p))
+
+		read, err = twoBit.readG2(bytes.NewReader(compressG2TwoBit(p)))
+		require.NoError(t, err)
+		require.True(t, read.Equal(p))
+	}
+
+	// the sign of y is the only difference between a point and its negation
+	b := compressG1TwoBit(&p1)
+	b[len(b)-1] ^= swFlagNegative
+	read, err := twoBit.readG1(bytes.NewReader(b))
+	require.NoError(t, err)
+	require.True(t, read.Equal(&n1))
+
+	// reading under the wrong scheme fails
+	_, err = threeBit.readG1(bytes.NewReader(compressG1TwoBit(&p1)))
+	require.Error(t, err)
+	zcash := p1.Bytes()
+	_, err = twoBit.readG1(bytes.NewReader(zcash[:]))
+	require.Error(t, err)
+
+	_, err = ReadCompressedProof(bytes.NewReader(nil), WithFlagScheme(FlagScheme(7)))
+	require.Error(t, err)
+}
+
+func TestReadCompressedTwoBit(t *testing.T) {
+	proof, vk, inputs := proofFixture(t)
+
+	var proofBytes bytes.Buffer
+	proofBytes.Write(compressG1TwoBit(&proof.Ar))
+	proofBytes.Write(compressG2TwoBit(&proof.Bs))
+	proofBytes.Write(compressG1TwoBit(&proof.Krs))
+
+	var vkBytes bytes.Buffer
+	vkBytes.Write(compressG1TwoBit(&vk.G1.Alpha))
+	for _, p := range []*curve.G2Affine{&vk.G2.Beta, &vk.G2.Gamma, &vk.G2.Delta} {
+		vkBytes.Write(compressG2TwoBit(p))
+	}
+	require.NoError(t, binary.Write(&vkBytes, binary.LittleEndian, uint64(len(vk.G1.K))))
+	for i := range vk.G1.K {
+		vkBytes.Write(compressG1TwoBit(&vk.G1.K[i]))
+	}
+
+	readProof, err := ReadCompressedProof(bytes.NewReader(proofBytes.Bytes()), WithFlagScheme(FlagScheme2Bit))
+	require.NoError(t, err)
+	readVK, err := ReadCompressedVerifyingKey(bytes.NewReader(vkBytes.Bytes()), WithFlagScheme(FlagScheme2Bit))
+	require.NoError(t, err)
+	require.NoError(t, Verify(readProof, readVK, inputs))
+
+	// the default scheme rejects the older encoding
+	_, err = ReadCompressedProof(bytes.NewReader(proofBytes.Bytes()))
+	require.Error(t, err)
+}
+
+// toMontgomery returns the element whose canonical value is the Montgomery
+// form x·R of e.
+func toMontgomery(e fp.Element) fp.Element {
+	v := e.BigInt(new(big.Int))
+	v.Lsh(v, 8*fp.Bytes).Mod(v, fp.Modulus())
+	var res fp.Element
+	res.SetBigInt(v)
+	return res
+}
+
+func TestCoordForms(t *testing.T) {
+	_, _, g1, g2 := curve.Generators()
+	var p1 curve.G1Affine
+	var p2 curve.G2Affine
+	p1.ScalarMultiplication(&g1, big.NewInt(6789))
+	p2.ScalarMultiplication(&g2, big.NewInt(6789))
+
+	// Montgomery-form encodings: the coordinates are replaced by their
+	// Montgomery form, the flags are those of the actual point
+	montgomeryZcashG1 := func(p *curve.G1Affine) []byte {
+		b := p.Bytes()
+		x := toMontgomery(p.X)
+		flags := b[0] &^ (0xff >> flagBits)
+		x.BigInt(new(big.Int)).FillBytes(b[:])
+		b[0] |= flags
+		return b[:]
+	}
+	montgomeryZcashG2 := func(p *curve.G2Affine) []byte {
+		b := p.Bytes()
+		a1, a0 := toMontgomery(p.X.A1), toMontgomery(p.X.A0)
+		flags := b[0] &^ (0xff >> flagBits)
+		a1.BigInt(new(big.Int)).FillBytes(b[:fp.Bytes])
+		a0.BigInt(new(big.Int)).FillBytes(b[fp.Bytes:])
+		b[0] |= flags
+		return b[:]
+	}
+
+	canonicalBytes := p1.Bytes()
+	require.NotEqual(t, canonicalBytes[:], montgomeryZcashG1(&p1))
+
+	for _, scheme := range []FlagScheme{FlagScheme3Bit, FlagScheme2Bit} {
+		canonical := readConfig{flagScheme: scheme}
+		montgomery := readConfig{flagScheme: scheme, coordForm: CoordMontgomery}
+
+		var encG1, encG2, montG1, montG2 []byte
+		if scheme == FlagScheme3Bit {
+			b1, b2 := p1.Bytes(), p2.Bytes()
+			encG1, encG2 = b1[:], b2[:]
+			montG1, montG2 = montgomeryZcashG1(&p1), montgomeryZcashG2(&p2)
+		} else {
+			encG1, encG2 = compressG1TwoBit(&p1), compressG2TwoBit(&p2)
+			m1, m2 := p1, p2
+			m1.X = toMontgomery(p1.X)
+			m2.X.A0, m2.X.A1 = toMontgomery(p2.X.A0), toMontgomery(p2.X.A1)
+			// y is left as is, so that the flags are those of the point
+			montG1, montG2 = compressG1TwoBit(&m1), compressG2TwoBit(&m2)
+		}
+
+		read1, err := canonical.readG1(bytes.NewReader(encG1))
+		require.NoError(t, err, scheme)
+		require.True(t, read1.Equal(&p1), scheme)
+		read1, err = montgomery.readG1(bytes.NewReader(montG1))
+		require.NoError(t, err, scheme)
+		require.True(t, read1.Equal(&p1), scheme)
+
+		read2, err := canonical.readG2(bytes.NewReader(encG2))
+		require.NoError(t, err, scheme)
+		require.True(t, read2.Equal(&p2), scheme)
+		read2, err = montgomery.readG2(bytes.NewReader(montG2))
+		require.NoError(t, err, scheme)
+		require.True(t, read2.Equal(&p2), scheme)
+
+		// Montgomery coordinates read as canonical don't decode to the point
+		if read1, err = canonical.readG1(bytes.NewReader(montG1)); err == nil {
+			require.False(t, read1.Equal(&p1), scheme)
+		}
+	}
+
+	_, err := ReadCompressedProof(bytes.NewReader(nil), WithCoordForm(CoordForm(3)))
+	require.Error(t, err)
+}
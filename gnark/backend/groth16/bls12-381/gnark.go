@@ -0,0 +1,27 @@
+package groth16
+
+import (
+	"io"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// WriteGnark writes the proof in the binary format of upstream gnark, as its
+// groth16.Proof.WriteTo does, so that a proof parsed from arkworks can be fed
+// to gnark tooling: compressed Ar | Bs | Krs, then the commitments as a
+// uint32-prefixed list and the commitment proof of knowledge.
+//
+// Points are written as is. gnark negates nothing in the proof: only the
+// verifying key holds negated points (gamma and delta, for the pairing
+// product), and those are recomputed by Precompute rather than serialized.
+// A proof read from arkworks has no commitments, which gnark reads back as an
+// empty list and a zero proof of knowledge.
+func (proof *Proof) WriteGnark(w io.Writer) (int64, error) {
+	enc := curve.NewEncoder(w)
+	for _, v := range []interface{}{&proof.Ar, &proof.Bs, &proof.Krs, proof.Commitments, &proof.CommitmentPok} {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	return enc.BytesWritten(), nil
+}
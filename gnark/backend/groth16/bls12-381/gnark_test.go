@@ -0,0 +1,49 @@
+package groth16
+
+import (
+	"bytes"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/stretchr/testify/require"
+)
+
+// readGnarkProof decodes a proof as upstream gnark's Proof.ReadFrom does.
+func readGnarkProof(t *testing.T, data []byte) *Proof {
+	var proof Proof
+	dec := curve.NewDecoder(bytes.NewReader(data))
+	for _, v := range []interface{}{&proof.Ar, &proof.Bs, &proof.Krs, &proof.Commitments, &proof.CommitmentPok} {
+		require.NoError(t, dec.Decode(v))
+	}
+	require.Equal(t, int64(len(data)), dec.BytesRead())
+	return &proof
+}
+
+func TestWriteGnark(t *testing.T) {
+	original, vk, publicWitness := proofFixture(t)
+
+	var proof Proof
+	_, err := proof.ReadFrom(bytes.NewReader(arkworksProofBytes(original)))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := proof.WriteGnark(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+
+	read := readGnarkProof(t, buf.Bytes())
+	require.True(t, read.Ar.Equal(&original.Ar))
+	require.True(t, read.Bs.Equal(&original.Bs))
+	require.True(t, read.Krs.Equal(&original.Krs))
+	require.Empty(t, read.Commitments)
+	require.NoError(t, Verify(read, vk, publicWitness))
+
+	// the native format is what WriteTo emits
+	var native bytes.Buffer
+	_, err = original.WriteTo(&native)
+	require.NoError(t, err)
+	buf.Reset()
+	_, err = original.WriteGnark(&buf)
+	require.NoError(t, err)
+	require.Equal(t, native.Bytes(), buf.Bytes())
+}
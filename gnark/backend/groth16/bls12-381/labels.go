@@ -0,0 +1,132 @@
+package groth16
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// InputLabelsMagic starts the label section trailing a verifying key in the
+// arkworks layout.
+const InputLabelsMagic = "GLBL"
+
+// maxInputLabelSize bounds the size of a label read from untrusted input.
+const maxInputLabelSize = 1 << 12
+
+// InputLabels returns the names of the public inputs, in the order of the
+// public witness, or nil if the verifying key has no label section.
+func (vk *VerifyingKey) InputLabels() []string {
+	return vk.inputLabels
+}
+
+// SetInputLabels sets the names of the public inputs, one per input of the
+// public witness. Labels must be non-empty and unique; nil removes them.
+func (vk *VerifyingKey) SetInputLabels(labels []string) error {
+	if labels != nil {
+		if err := vk.checkInputLabels(labels); err != nil {
+			return err
+		}
+	}
+	vk.inputLabels = labels
+	return nil
+}
+
+func (vk *VerifyingKey) checkInputLabels(labels []string) error {
+	if len(labels) != vk.nbPublicInputs() {
+		return fmt.Errorf("got %d labels for %d public inputs", len(labels), vk.nbPublicInputs())
+	}
+	seen := make(map[string]bool, len(labels))
+	for i, l := range labels {
+		if l == "" || len(l) > maxInputLabelSize || !utf8.ValidString(l) {
+			return fmt.Errorf("invalid label %d", i)
+		}
+		if seen[l] {
+			return fmt.Errorf("duplicate label %q", l)
+		}
+		seen[l] = true
+	}
+	return nil
+}
+
+// WriteInputLabelsTo writes the label section, meant to trail the verifying
+// key in the arkworks layout (see WriteArkworksTo): the magic "GLBL", then the labels as an arkworks Vec<String>,
+// that is a little-endian uint64 count followed by each label as a
+// little-endian uint64 length and its UTF-8 bytes. Nothing is written if the
+// key has no labels.
+func (vk *VerifyingKey) WriteInputLabelsTo(w io.Writer) (int64, error) {
+	if vk.inputLabels == nil {
+		return 0, nil
+	}
+	var buf bytes.Buffer
+	buf.WriteString(InputLabelsMagic)
+	buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(vk.inputLabels))))
+	for _, l := range vk.inputLabels {
+		buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(l))))
+		buf.WriteString(l)
+	}
+	return buf.WriteTo(w)
+}
+
+// ReadInputLabelsFrom reads the label section written by WriteInputLabelsTo,
+// once the verifying key itself has been read from r. A reader at its end
+// means the key has no labels.
+func (vk *VerifyingKey) ReadInputLabelsFrom(r io.Reader) (int64, error) {
+	var magic [len(InputLabelsMagic)]byte
+	n, err := io.ReadFull(r, magic[:])
+	read := int64(n)
+	if err == io.EOF {
+		vk.inputLabels = nil
+		return 0, nil
+	}
+	if err != nil {
+		return read, err
+	}
+	if string(magic[:]) != InputLabelsMagic {
+		return read, errors.New("unknown trailing section in verifying key")
+	}
+
+	readUint64 := func() (uint64, error) {
+		var b [8]byte
+		n, err := io.ReadFull(r, b[:])
+		read += int64(n)
+		return binary.LittleEndian.Uint64(b[:]), err
+	}
+	count, err := readUint64()
+	if err != nil {
+		return read, err
+	}
+	if count != uint64(vk.nbPublicInputs()) {
+		return read, fmt.Errorf("got %d labels for %d public inputs", count, vk.nbPublicInputs())
+	}
+	labels := make([]string, count)
+	for i := range labels {
+		size, err := readUint64()
+		if err != nil {
+			return read, err
+		}
+		if size > maxInputLabelSize {
+			return read, fmt.Errorf("label %d too long", i)
+		}
+		b := make([]byte, size)
+		n, err := io.ReadFull(r, b)
+		read += int64(n)
+		if err != nil {
+			return read, err
+		}
+		labels[i] = string(b)
+	}
+	if err := vk.checkInputLabels(labels); err != nil {
+		return read, err
+	}
+	vk.inputLabels = labels
+	return read, nil
+}
+
+// nbPublicInputs returns the number of public inputs, without the constant 1
+// wire and the commitment wires.
+func (vk *VerifyingKey) nbPublicInputs() int {
+	return vk.nbIC() - len(vk.PublicAndCommitmentCommitted) - 1
+}
@@ -0,0 +1,98 @@
+package groth16
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputLabels(t *testing.T) {
+	_, vk, _ := proofFixture(t)
+	require.Nil(t, vk.InputLabels())
+
+	require.Error(t, vk.SetInputLabels([]string{"Y"}))
+	require.Error(t, vk.SetInputLabels([]string{"Y", "Y"}))
+	require.Error(t, vk.SetInputLabels([]string{"Y", ""}))
+	require.NoError(t, vk.SetInputLabels([]string{"Y", "Unused"}))
+
+	var buf bytes.Buffer
+	n, err := vk.WriteInputLabelsTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+
+	read := *vk
+	read.inputLabels = nil
+	n, err = read.ReadInputLabelsFrom(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+	require.Equal(t, []string{"Y", "Unused"}, read.InputLabels())
+
+	// no section
+	n, err = read.ReadInputLabelsFrom(bytes.NewReader(nil))
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Nil(t, read.InputLabels())
+
+	// truncated, or for another key
+	_, err = read.ReadInputLabelsFrom(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	require.Error(t, err)
+	other := read
+	other.G1.K = other.G1.K[:2]
+	_, err = other.ReadInputLabelsFrom(bytes.NewReader(buf.Bytes()))
+	require.ErrorContains(t, err, "got 2 labels for 1 public inputs")
+	_, err = read.ReadInputLabelsFrom(bytes.NewReader([]byte("ABCD")))
+	require.Error(t, err)
+
+	require.NoError(t, vk.SetInputLabels(nil))
+	buf.Reset()
+	_, err = vk.WriteInputLabelsTo(&buf)
+	require.NoError(t, err)
+	require.Zero(t, buf.Len())
+}
+
+func TestParseVerifyingKeyLabels(t *testing.T) {
+	proof, vk, publicWitness := proofFixture(t)
+	require.NoError(t, vk.SetInputLabels([]string{"Y", "Unused"}))
+
+	for _, writeTo := range []func(io.Writer) (int64, error){vk.WriteArkworksTo, vk.WriteArkworksRawTo} {
+		var buf bytes.Buffer
+		n, err := writeTo(&buf)
+		require.NoError(t, err)
+		require.Equal(t, int64(buf.Len()), n)
+
+		parsed, err := ParseVerifyingKey(buf.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, []string{"Y", "Unused"}, parsed.InputLabels())
+		require.Equal(t, vk.G1.K, parsed.G1.K)
+		require.NoError(t, Verify(proof, parsed, publicWitness))
+
+		_, err = ParseVerifyingKey(append(buf.Bytes(), 0))
+		require.ErrorContains(t, err, "1 trailing bytes")
+	}
+
+	// the labels don't change the gnark encoding, nor the key identity
+	var withLabels, without bytes.Buffer
+	_, err := vk.WriteRawTo(&withLabels)
+	require.NoError(t, err)
+	unlabeled := *vk
+	unlabeled.inputLabels = nil
+	_, err = unlabeled.WriteRawTo(&without)
+	require.NoError(t, err)
+	require.Equal(t, without.Bytes(), withLabels.Bytes())
+
+	var buf bytes.Buffer
+	_, err = unlabeled.WriteArkworksTo(&buf)
+	require.NoError(t, err)
+	parsed, err := ParseVerifyingKey(buf.Bytes())
+	require.NoError(t, err)
+	require.Nil(t, parsed.InputLabels())
+	_, err = ParseVerifyingKey(append(buf.Bytes(), "ABCD"...))
+	require.ErrorContains(t, err, "input labels")
+
+	committed := unlabeled
+	committed.PublicAndCommitmentCommitted = [][]int{{1}}
+	_, err = committed.WriteArkworksTo(&buf)
+	require.Error(t, err)
+}
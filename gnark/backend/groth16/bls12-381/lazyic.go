@@ -0,0 +1,99 @@
+package groth16
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// lazyICChunk is the number of IC points generated at once by a lazy verifying
+// key, bounding the memory of the multi-exponentiation.
+const lazyICChunk = 1 << 12
+
+type lazyIC struct {
+	n     int
+	point func(i int) curve.G1Affine
+}
+
+// VerifyingKeyWithLazyIC returns a verifying key whose icLen IC points are
+// computed by icFunc when verifying instead of stored in G1.K, for circuits
+// with many public inputs whose IC follows a structure (e.g. powers of a base
+// point). icFunc(0) is the point of the constant 1 wire and icFunc(i) the
+// point of the i-th public input; the points are generated in chunks as the
+// multi-exponentiation needs them, and are not checked to be in G1: icFunc is
+// trusted as the rest of the key.
+//
+// The other elements (α, β, γ, δ) are taken from fixed, whose G1.K must be
+// empty. Commitments are not supported, and the key can't be serialized.
+func VerifyingKeyWithLazyIC(fixed *VerifyingKey, icLen int, icFunc func(i int) curve.G1Affine) (*VerifyingKey, error) {
+	switch {
+	case icFunc == nil:
+		return nil, errors.New("missing IC function")
+	case icLen < 1:
+		return nil, errors.New("IC must have at least the point of the constant 1 wire")
+	case len(fixed.G1.K) != 0:
+		return nil, errors.New("fixed elements must not include IC points")
+	case len(fixed.PublicAndCommitmentCommitted) != 0:
+		return nil, errors.New("lazy IC doesn't support commitments")
+	}
+	vk := new(VerifyingKey)
+	vk.G1.Alpha, vk.G1.Beta, vk.G1.Delta = fixed.G1.Alpha, fixed.G1.Beta, fixed.G1.Delta
+	vk.G2.Beta, vk.G2.Gamma, vk.G2.Delta = fixed.G2.Beta, fixed.G2.Gamma, fixed.G2.Delta
+	vk.PublicAndCommitmentCommitted = [][]int{}
+	vk.lazyIC = &lazyIC{n: icLen, point: icFunc}
+	if err := vk.Precompute(); err != nil {
+		return nil, err
+	}
+	return vk, nil
+}
+
+// nbIC returns the number of IC points.
+func (vk *VerifyingKey) nbIC() int {
+	if vk.lazyIC != nil {
+		return vk.lazyIC.n
+	}
+	return len(vk.G1.K)
+}
+
+// icPoint returns the i-th IC point.
+func (vk *VerifyingKey) icPoint(i int) curve.G1Affine {
+	if vk.lazyIC != nil {
+		return vk.lazyIC.point(i)
+	}
+	return vk.G1.K[i]
+}
+
+// publicInputPoint returns K₀ + Σ scalars[i]·Kᵢ₊₁.
+func (vk *VerifyingKey) publicInputPoint(scalars []fr.Element) (curve.G1Jac, error) {
+	res, err := vk.icCombination(1, scalars)
+	if err != nil {
+		return res, err
+	}
+	k0 := vk.icPoint(0)
+	res.AddMixed(&k0)
+	return res, nil
+}
+
+// icCombination returns Σ scalars[i]·K[first+i].
+func (vk *VerifyingKey) icCombination(first int, scalars []fr.Element) (curve.G1Jac, error) {
+	if vk.lazyIC == nil {
+		return linearCombination(vk.G1.K[first:], scalars)
+	}
+
+	var res curve.G1Jac
+	res.FromAffine(&curve.G1Affine{})
+	points := make([]curve.G1Affine, min(len(scalars), lazyICChunk))
+	for start := 0; start < len(scalars); start += len(points) {
+		chunk := scalars[start:min(start+len(points), len(scalars))]
+		for j := range chunk {
+			points[j] = vk.lazyIC.point(first + start + j)
+		}
+		sum, err := linearCombination(points[:len(chunk)], chunk)
+		if err != nil {
+			return res, err
+		}
+		res.AddAssign(&sum)
+	}
+	return res, nil
+}
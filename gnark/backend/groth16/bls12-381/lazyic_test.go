@@ -0,0 +1,66 @@
+package groth16
+
+import (
+	"bytes"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyLazyIC(t *testing.T) {
+	proof, vk, publicWitness := proofFixture(t)
+
+	fixed := *vk
+	fixed.G1.K = nil
+	fixed.PublicAndCommitmentCommitted = nil
+	lazy, err := VerifyingKeyWithLazyIC(&fixed, len(vk.G1.K), func(i int) curve.G1Affine { return vk.G1.K[i] })
+	require.NoError(t, err)
+
+	require.NoError(t, Verify(proof, lazy, publicWitness))
+	require.Equal(t, vk.NbPublicWitness(), lazy.NbPublicWitness())
+	require.Equal(t, vk.NbG1(), lazy.NbG1())
+	require.False(t, lazy.IsDifferent(vk))
+	require.False(t, vk.IsDifferent(lazy))
+	require.NoError(t, ValidateBatch([]Task{{Proof: proof, VerifyingKey: lazy, PublicWitness: publicWitness}}))
+	task := Task{Proof: proof, VerifyingKey: lazy, PublicWitness: publicWitness}
+	require.Equal(t, []error{nil, nil}, VerifySmartBatch([]Task{task, task}))
+
+	wrong := append(fr.Vector{}, publicWitness...)
+	wrong[0].SetUint64(42)
+	require.ErrorIs(t, Verify(proof, vk, wrong), errPairingCheckFailed)
+	require.ErrorIs(t, Verify(proof, lazy, wrong), errPairingCheckFailed)
+	require.Error(t, Verify(proof, lazy, publicWitness[1:]))
+
+	_, err = lazy.WriteTo(new(bytes.Buffer))
+	require.ErrorIs(t, err, errNotSerializable)
+
+	_, err = VerifyingKeyWithLazyIC(vk, len(vk.G1.K), func(i int) curve.G1Affine { return vk.G1.K[i] })
+	require.Error(t, err, "fixed elements with IC points")
+}
+
+func TestLazyICChunks(t *testing.T) {
+	// powers of the generator: Kᵢ = (i+1)·G
+	n := 2*lazyICChunk + 3
+	_, _, g1, _ := curve.Generators()
+	var vk VerifyingKey
+	vk.G1.K = make([]curve.G1Affine, n)
+	vk.G1.K[0] = g1
+	for i := 1; i < n; i++ {
+		vk.G1.K[i].Add(&vk.G1.K[i-1], &g1)
+	}
+	lazy := VerifyingKey{lazyIC: &lazyIC{n: n, point: func(i int) curve.G1Affine { return vk.G1.K[i] }}}
+
+	for _, m := range []int{0, 1, lazyICChunk, n - 1} {
+		scalars := make([]fr.Element, m)
+		for i := range scalars {
+			scalars[i].SetRandom()
+		}
+		expected, err := vk.publicInputPoint(scalars)
+		require.NoError(t, err)
+		got, err := lazy.publicInputPoint(scalars)
+		require.NoError(t, err)
+		require.True(t, expected.Equal(&got), "%d scalars", m)
+	}
+}
@@ -12,8 +12,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Code generated by gnark DO NOT EDIT
-
 package groth16
 
 import (
@@ -117,6 +115,12 @@ func (vk *VerifyingKey) WriteRawTo(w io.Writer) (n int64, err error) {
 // https://github.com/zkcrypto/bellman/blob/fa9be45588227a8c6ec34957de3f68705f07bd92/src/groth16/mod.rs#L143
 // [α]1,[β]1,[β]2,[γ]2,[δ]1,[δ]2,uint32(len(Kvk)),[Kvk]1
 func (vk *VerifyingKey) writeTo(w io.Writer, raw bool) (int64, error) {
+	if vk.preparedOnly() {
+		return 0, errPreparedOnly
+	}
+	if !vk.serializable() {
+		return 0, errNotSerializable
+	}
 	var enc *curve.Encoder
 	if raw {
 		enc = curve.NewEncoder(w, curve.RawEncoding())
@@ -0,0 +1,79 @@
+package groth16
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark/backend"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMetrics struct {
+	lock     sync.Mutex
+	stages   []string
+	counters map[string]int
+}
+
+func (m *fakeMetrics) ObserveDuration(stage string, d time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.stages = append(m.stages, stage)
+}
+
+func (m *fakeMetrics) IncCounter(name string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.counters == nil {
+		m.counters = make(map[string]int)
+	}
+	m.counters[name]++
+}
+
+func TestVerifyMetrics(t *testing.T) {
+	proof, vk, publicWitness := proofFixture(t)
+
+	m := new(fakeMetrics)
+	require.NoError(t, Verify(proof, vk, publicWitness, backend.WithVerifierMetrics(m)))
+	require.Equal(t, []string{
+		backend.MetricsStageSubgroup,
+		backend.MetricsStageCommitments,
+		backend.MetricsStageMSM,
+		backend.MetricsStagePairing,
+	}, m.stages)
+	require.Equal(t, map[string]int{backend.MetricsVerifySuccess: 1}, m.counters)
+
+	m = new(fakeMetrics)
+	require.Error(t, Verify(proof, vk, publicWitness[:1], backend.WithVerifierMetrics(m)))
+	require.Empty(t, m.stages)
+	require.Equal(t, map[string]int{backend.MetricsVerifyFailure: 1}, m.counters)
+
+	m = new(fakeMetrics)
+	require.NoError(t, VerifyOptimistic(arkworksProofBytes(proof), vk, publicWitness, backend.WithVerifierMetrics(m)))
+	require.Equal(t, backend.MetricsStageParse, m.stages[0])
+	require.Equal(t, map[string]int{backend.MetricsVerifySuccess: 1}, m.counters)
+
+	require.NoError(t, Verify(proof, vk, publicWitness, backend.WithVerifierMetrics(backend.NopMetrics{})))
+}
+
+func TestVerifySmartBatchMetrics(t *testing.T) {
+	tasks := batchTasks(t, 3)
+
+	m := new(fakeMetrics)
+	for _, err := range VerifySmartBatch(tasks, backend.WithVerifierMetrics(m)) {
+		require.NoError(t, err)
+	}
+	require.Contains(t, m.stages, backend.MetricsStageBatchPairing)
+	require.Equal(t, map[string]int{backend.MetricsVerifySuccess: 3}, m.counters)
+
+	bad := *tasks[0].Proof
+	bad.Krs = tasks[1].Proof.Krs
+	tasks[0].Proof = &bad
+	m = new(fakeMetrics)
+	VerifySmartBatch(tasks, backend.WithVerifierMetrics(m))
+	require.Equal(t, map[string]int{
+		backend.MetricsBatchFallback: 1,
+		backend.MetricsVerifySuccess: 2,
+		backend.MetricsVerifyFailure: 1,
+	}, m.counters)
+}
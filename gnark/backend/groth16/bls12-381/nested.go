@@ -0,0 +1,98 @@
+package groth16
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/backend"
+)
+
+// NestedDigestDST is the domain separation tag of NestedDigest.
+const NestedDigestDST = "GNARK-GROTH16-NESTED-V1"
+
+var (
+	errNestingTooDeep  = errors.New("nested artifact exceeds the maximum depth")
+	errNestedDigest    = errors.New("last public input doesn't commit to the inner artifact")
+	errNoDigestInput   = errors.New("outer proof has no public input to commit to the inner artifact")
+	errInvalidMaxDepth = errors.New("maximum depth must be positive")
+)
+
+// NestedArtifact is a proof whose public inputs commit to another proof, down
+// to a proof with no Inner artifact. The convention is that when Inner is set,
+// the last public input of the proof equals NestedDigest(Inner); other inputs
+// are free.
+type NestedArtifact struct {
+	Task
+	Inner *NestedArtifact
+}
+
+// NestedDigest returns the public input committing to a, the field element
+//
+//	hash_to_field(vk ‖ proof ‖ x₀ ‖ … ‖ xₙ₋₁)
+//
+// with the RFC 9380 hash_to_field of fr.Hash and NestedDigestDST, vk and proof
+// as written by WriteRawTo and each public input xᵢ as 32 bytes big-endian.
+// Since the inputs of a include the digest of its own inner artifact, the
+// digest binds the whole chain below a.
+func NestedDigest(a *NestedArtifact) (fr.Element, error) {
+	if a.Proof == nil || a.VerifyingKey == nil {
+		return fr.Element{}, errors.New("missing proof or verifying key")
+	}
+	var buf bytes.Buffer
+	if _, err := a.VerifyingKey.WriteRawTo(&buf); err != nil {
+		return fr.Element{}, err
+	}
+	if _, err := a.Proof.WriteRawTo(&buf); err != nil {
+		return fr.Element{}, err
+	}
+	var v big.Int
+	for i := range a.PublicWitness {
+		buf.Write(a.PublicWitness[i].BigInt(&v).FillBytes(make([]byte, fr.Bytes)))
+	}
+	h, err := fr.Hash(buf.Bytes(), []byte(NestedDigestDST), 1)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	return h[0], nil
+}
+
+// VerifyNested verifies artifact and the artifacts nested in it, outermost
+// first, checking at each level that the last public input is the digest of
+// the inner artifact. The outer artifact is at depth 1 and artifacts nesting
+// more than maxDepth levels are rejected before any verification, which also
+// guards against cyclic artifacts.
+func VerifyNested(artifact *NestedArtifact, maxDepth int, opts ...backend.VerifierOption) error {
+	if maxDepth < 1 {
+		return errInvalidMaxDepth
+	}
+	depth := 0
+	for a := artifact; a != nil; a = a.Inner {
+		if depth++; depth > maxDepth {
+			return fmt.Errorf("%w (%d)", errNestingTooDeep, maxDepth)
+		}
+	}
+
+	depth = 1
+	for a := artifact; a != nil; a, depth = a.Inner, depth+1 {
+		if err := Verify(a.Proof, a.VerifyingKey, a.PublicWitness, opts...); err != nil {
+			return fmt.Errorf("depth %d: %w", depth, err)
+		}
+		if a.Inner == nil {
+			break
+		}
+		if len(a.PublicWitness) == 0 {
+			return fmt.Errorf("depth %d: %w", depth, errNoDigestInput)
+		}
+		digest, err := NestedDigest(a.Inner)
+		if err != nil {
+			return fmt.Errorf("depth %d: %w", depth+1, err)
+		}
+		if !digest.Equal(&a.PublicWitness[len(a.PublicWitness)-1]) {
+			return fmt.Errorf("depth %d: %w", depth, errNestedDigest)
+		}
+	}
+	return nil
+}
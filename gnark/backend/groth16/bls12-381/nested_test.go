@@ -0,0 +1,61 @@
+package groth16
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	cs "github.com/consensys/gnark/constraint/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyNested(t *testing.T) {
+	ccs, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &unusedInputCircuit{}, frontend.IgnoreUnconstrainedInputs())
+	require.NoError(t, err)
+	system := ccs.(*cs.R1CS)
+	var (
+		pk ProvingKey
+		vk VerifyingKey
+	)
+	require.NoError(t, Setup(system, &pk, &vk))
+
+	// the unconstrained public input carries the digest of the inner artifact
+	level := func(inner *NestedArtifact) *NestedArtifact {
+		var digest fr.Element
+		if inner != nil {
+			digest, err = NestedDigest(inner)
+			require.NoError(t, err)
+		}
+		w, err := frontend.NewWitness(&unusedInputCircuit{X: 3, Y: 9, Unused: digest}, ecc.BLS12_381.ScalarField())
+		require.NoError(t, err)
+		proof, err := Prove(system, &pk, w)
+		require.NoError(t, err)
+		publicWitness, err := w.Public()
+		require.NoError(t, err)
+		return &NestedArtifact{Task: Task{proof, &vk, publicWitness.Vector().(fr.Vector)}, Inner: inner}
+	}
+
+	inner := level(nil)
+	outer := level(inner)
+	require.NoError(t, VerifyNested(outer, 2))
+	require.ErrorIs(t, VerifyNested(outer, 1), errNestingTooDeep)
+	require.ErrorIs(t, VerifyNested(outer, 0), errInvalidMaxDepth)
+
+	// a cycle is caught by the depth limit
+	cyclic := *outer
+	cyclic.Inner = &cyclic
+	require.ErrorIs(t, VerifyNested(&cyclic, 10), errNestingTooDeep)
+
+	// swapping the inner artifact breaks the link
+	broken := *outer
+	broken.Inner = level(nil)
+	require.ErrorIs(t, VerifyNested(&broken, 2), errNestedDigest)
+
+	// each level is verified
+	tampered := *inner
+	tampered.PublicWitness = fr.Vector{inner.PublicWitness[0], inner.PublicWitness[1]}
+	tampered.PublicWitness[0].SetUint64(10)
+	require.ErrorIs(t, VerifyNested(&tampered, 1), errPairingCheckFailed)
+}
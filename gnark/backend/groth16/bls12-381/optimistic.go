@@ -0,0 +1,63 @@
+package groth16
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/backend"
+)
+
+// UnsafeReadFrom reads the points of an arkworks proof (Ar, Bs, Krs), compressed
+// or not, without checking that they are in the correct subgroups. Uncompressed
+// points are not checked to be on the curve either; Verify does it.
+func (proof *Proof) UnsafeReadFrom(r io.Reader) (int64, error) {
+	dec := curve.NewDecoder(r, curve.NoSubgroupChecks())
+	for _, p := range []interface{}{&proof.Ar, &proof.Bs, &proof.Krs} {
+		if err := dec.Decode(p); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+	return dec.BytesRead(), nil
+}
+
+// VerifyOptimistic parses an arkworks proof and verifies it, checking that its
+// points are on the curves but not that they are in the prime order subgroups,
+// which saves the subgroup checks of ReadFrom and Verify (about a quarter of
+// the time to read and verify a proof with few public inputs).
+//
+// The pairing equation does not enforce subgroup membership. The points of
+// E(Fp) and E'(Fp²) have components of small order (the cofactors of
+// BLS12-381 G1 and G2 have small prime factors), and the optimal ate pairing
+// is only bilinear on G1 × G2: outside of it nothing relates the equation to
+// the Groth16 knowledge soundness argument, which assumes prime order groups.
+// In particular, given a valid proof, adding torsion to its points may give
+// other proofs which verify, so proofs are malleable and must not be used as
+// identifiers (e.g. nullifiers, deduplication keys).
+//
+// It is therefore only sound if the proof points are known to be in the
+// subgroups by other means: produced by a trusted prover, or already checked
+// upstream (e.g. by QuickReject or by the submitter's gateway) and transported
+// over an authenticated channel. Untrusted input must use Verify.
+func VerifyOptimistic(proofBytes []byte, vk *VerifyingKey, publicWitness fr.Vector, opts ...backend.VerifierOption) error {
+	opt, err := backend.NewVerifierConfig(opts...)
+	if err != nil {
+		return fmt.Errorf("new verifier config: %w", err)
+	}
+	timer := newStageTimer(opt.Metrics)
+
+	var proof Proof
+	n, err := proof.UnsafeReadFrom(bytes.NewReader(proofBytes))
+	if err == nil && int(n) != len(proofBytes) {
+		err = errors.New("trailing bytes after the proof")
+	}
+	if err != nil {
+		timer.count(err)
+		return err
+	}
+	timer.done(backend.MetricsStageParse)
+	return verify(&proof, vk, publicWitness, nil, true, opts...)
+}
@@ -0,0 +1,77 @@
+package groth16
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	cs "github.com/consensys/gnark/constraint/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+// arkworksProofBytes serializes the points of proof the way arkworks does.
+func arkworksProofBytes(proof *Proof) []byte {
+	ar, bs, krs := proof.Ar.RawBytes(), proof.Bs.RawBytes(), proof.Krs.Bytes()
+	res := append(ar[:], bs[:]...)
+	return append(res, krs[:]...)
+}
+
+func proofFixture(tb testing.TB) (*Proof, *VerifyingKey, fr.Vector) {
+	ccs, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &unusedInputCircuit{}, frontend.IgnoreUnconstrainedInputs())
+	require.NoError(tb, err)
+	var (
+		pk ProvingKey
+		vk VerifyingKey
+	)
+	require.NoError(tb, Setup(ccs.(*cs.R1CS), &pk, &vk))
+	w, err := frontend.NewWitness(&unusedInputCircuit{X: 3, Y: 9, Unused: 1}, ecc.BLS12_381.ScalarField())
+	require.NoError(tb, err)
+	proof, err := Prove(ccs.(*cs.R1CS), &pk, w)
+	require.NoError(tb, err)
+	publicWitness, err := w.Public()
+	require.NoError(tb, err)
+	return proof, &vk, publicWitness.Vector().(fr.Vector)
+}
+
+func TestVerifyOptimistic(t *testing.T) {
+	proof, vk, publicWitness := proofFixture(t)
+	proofBytes := arkworksProofBytes(proof)
+
+	require.NoError(t, VerifyOptimistic(proofBytes, vk, publicWitness))
+	require.Error(t, VerifyOptimistic(append(proofBytes, 0), vk, publicWitness))
+
+	// uncompressed points off the curve are rejected
+	offCurve := *proof
+	offCurve.Ar.Y.Double(&offCurve.Ar.Y)
+	require.ErrorIs(t, VerifyOptimistic(arkworksProofBytes(&offCurve), vk, publicWitness), errCorrectSubgroupCheckFailed)
+
+	// points outside of the subgroup are only caught by the explicit checks
+	bad1, _ := outsideSubgroup(t)
+	outside := *proof
+	outside.Krs = bad1
+	require.True(t, outside.isOnCurve())
+	require.ErrorIs(t, Verify(&outside, vk, publicWitness), errCorrectSubgroupCheckFailed)
+	require.ErrorIs(t, VerifyOptimistic(arkworksProofBytes(&outside), vk, publicWitness), errPairingCheckFailed)
+}
+
+func BenchmarkVerifyOptimistic(b *testing.B) {
+	proof, vk, publicWitness := proofFixture(b)
+	proofBytes := arkworksProofBytes(proof)
+	b.Run("checked", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var p Proof
+			if _, err := p.ReadFrom(bytes.NewReader(proofBytes)); err != nil {
+				b.Fatal(err)
+			}
+			_ = Verify(&p, vk, publicWitness)
+		}
+	})
+	b.Run("optimistic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = VerifyOptimistic(proofBytes, vk, publicWitness)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package groth16
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var errNotSerializable = errors.New("verifying key with a lazy or split IC can't be serialized")
+
+// icPart is a part of a split IC: the IC points of the public inputs from
+// start on, up to the next part, are paired with the γ of the part instead of
+// that of the verifying key. Parts are sorted by start.
+type icPart struct {
+	start           int // index of the first public input of the part, ≥ 1
+	gamma, gammaNeg curve.G2Affine
+}
+
+// pairingTerms is the structure of the verification equation, derived from the
+// verifying key:
+//
+//	e(Ar, Bs) · e(Krs, -δ) · ∏ⱼ e(Lⱼ, -γⱼ) == e(α, β)
+//
+// where the IC is split in parts, Lⱼ being the combination of the IC points of
+// part j with their public inputs. The first part, paired with the γ of the
+// key, also holds K₀ and the commitments. Standard Groth16 has a single part:
+// with e(α, β) precomputed, the Miller loop has three terms, four pairings in
+// all. Variants of the scheme pairing some inputs with another G2 point (e.g.
+// to keep committed inputs apart) only add parts, and verify assembles the
+// Miller loop from the terms.
+type pairingTerms struct {
+	// bounds[j] is the index in the public witness of the first input of part
+	// j+1; the last part runs to the end, including the commitment wires.
+	bounds   []int
+	gammaNeg []curve.G2Affine // -γⱼ
+}
+
+func (vk *VerifyingKey) pairingTerms() pairingTerms {
+	t := pairingTerms{gammaNeg: []curve.G2Affine{vk.G2.gammaNeg}}
+	for _, p := range vk.icParts {
+		t.bounds = append(t.bounds, p.start-1)
+		t.gammaNeg = append(t.gammaNeg, p.gammaNeg)
+	}
+	return t
+}
+
+// icTerms returns the G1 points Lⱼ paired with the -γⱼ.
+func (t *pairingTerms) icTerms(vk *VerifyingKey, publicWitness fr.Vector, commitments []curve.G1Affine) ([]curve.G1Affine, error) {
+	res := make([]curve.G1Affine, len(t.gammaNeg))
+	start := 0
+	for j := range res {
+		end := len(publicWitness)
+		if j < len(t.bounds) {
+			end = t.bounds[j]
+		}
+		if end < start || end > len(publicWitness) {
+			return nil, errors.New("invalid IC part bounds")
+		}
+		sum, err := vk.icCombination(start+1, publicWitness[start:end])
+		if err != nil {
+			return nil, err
+		}
+		if j == 0 {
+			k0 := vk.icPoint(0)
+			sum.AddMixed(&k0)
+			for i := range commitments {
+				sum.AddMixed(&commitments[i])
+			}
+		}
+		res[j].FromJacobian(&sum)
+		start = end
+	}
+	return res, nil
+}
+
+// serializable reports whether the key is fully described by its fields.
+func (vk *VerifyingKey) serializable() bool {
+	return vk.lazyIC == nil && len(vk.icParts) == 0 && !vk.preparedOnly()
+}
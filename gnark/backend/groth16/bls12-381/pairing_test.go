@@ -0,0 +1,61 @@
+package groth16
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPairingTerms(t *testing.T) {
+	proof, vk, publicWitness := proofFixture(t)
+
+	terms := vk.pairingTerms()
+	require.Len(t, terms.gammaNeg, 1)
+	require.Empty(t, terms.bounds)
+	icTerms, err := terms.icTerms(vk, publicWitness, proof.Commitments)
+	require.NoError(t, err)
+	kSum, err := vk.publicInputPoint(publicWitness)
+	require.NoError(t, err)
+	var kSumAff curve.G1Affine
+	kSumAff.FromJacobian(&kSum)
+	require.Equal(t, []curve.G1Affine{kSumAff}, icTerms)
+	require.NoError(t, Verify(proof, vk, publicWitness))
+
+	// synthetic variant: the public inputs are paired with γ' = s·γ, their IC
+	// points being Kᵢ/s, so that e(xᵢ·Kᵢ/s, -s·γ) = e(xᵢ·Kᵢ, -γ), while K₀ stays
+	// paired with γ
+	require.Len(t, publicWitness, 2)
+	var s, sInv fr.Element
+	s.SetUint64(7)
+	sInv.Inverse(&s)
+	split := *vk
+	split.G1.K = append([]curve.G1Affine{}, vk.G1.K...)
+	for i := 1; i < len(split.G1.K); i++ {
+		split.G1.K[i].ScalarMultiplication(&vk.G1.K[i], sInv.BigInt(new(big.Int)))
+	}
+	var gamma curve.G2Affine
+	gamma.ScalarMultiplication(&vk.G2.Gamma, s.BigInt(new(big.Int)))
+	split.icParts = []icPart{{start: 1, gamma: gamma}}
+	require.NoError(t, split.Precompute())
+
+	terms = split.pairingTerms()
+	require.Len(t, terms.gammaNeg, 2)
+	require.NoError(t, Verify(proof, &split, publicWitness))
+	wrong := append(fr.Vector{}, publicWitness...)
+	wrong[0].SetUint64(42)
+	require.ErrorIs(t, Verify(proof, &split, wrong), errPairingCheckFailed)
+
+	task := Task{Proof: proof, VerifyingKey: &split, PublicWitness: publicWitness}
+	require.Equal(t, []error{nil, nil}, VerifySmartBatch([]Task{task, task}))
+	_, err = split.WriteTo(new(bytes.Buffer))
+	require.ErrorIs(t, err, errNotSerializable)
+
+	// the part must be paired with its own γ
+	split.icParts = []icPart{{start: 1, gamma: vk.G2.Gamma}}
+	require.NoError(t, split.Precompute())
+	require.ErrorIs(t, Verify(proof, &split, publicWitness), errPairingCheckFailed)
+}
@@ -0,0 +1,128 @@
+package groth16
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/backend"
+)
+
+var errPreimageMismatch = errors.New("public input doesn't match the hash of the preimage")
+
+// PoseidonParams are the parameters of a Poseidon sponge over fr, named after
+// the fields of arkworks PoseidonConfig. The state is made of Capacity
+// elements followed by Rate elements, ARK holds the round constants of each of
+// the FullRounds+PartialRounds rounds and MDS is the (Rate+Capacity)² mixing
+// matrix.
+//
+// The parameters are not generated here; they must match the ones used by the
+// circuit.
+type PoseidonParams struct {
+	FullRounds    int
+	PartialRounds int
+	Alpha         uint64
+	MDS           [][]fr.Element
+	ARK           [][]fr.Element
+	Rate          int
+	Capacity      int
+}
+
+func (p *PoseidonParams) check() error {
+	width := p.Rate + p.Capacity
+	if p.Rate <= 0 || p.Capacity < 0 {
+		return fmt.Errorf("poseidon: invalid rate %d and capacity %d", p.Rate, p.Capacity)
+	}
+	if p.FullRounds%2 != 0 {
+		return fmt.Errorf("poseidon: odd number of full rounds %d", p.FullRounds)
+	}
+	if p.Alpha < 2 {
+		return fmt.Errorf("poseidon: invalid alpha %d", p.Alpha)
+	}
+	if len(p.ARK) != p.FullRounds+p.PartialRounds {
+		return fmt.Errorf("poseidon: expected %d round constant vectors, got %d", p.FullRounds+p.PartialRounds, len(p.ARK))
+	}
+	for i := range p.ARK {
+		if len(p.ARK[i]) != width {
+			return fmt.Errorf("poseidon: round %d: expected %d round constants, got %d", i, width, len(p.ARK[i]))
+		}
+	}
+	if len(p.MDS) != width {
+		return fmt.Errorf("poseidon: expected %d MDS rows, got %d", width, len(p.MDS))
+	}
+	for i := range p.MDS {
+		if len(p.MDS[i]) != width {
+			return fmt.Errorf("poseidon: MDS row %d: expected %d elements, got %d", i, width, len(p.MDS[i]))
+		}
+	}
+	return nil
+}
+
+// Hash absorbs preimage into the rate of an all-zero state, permuting whenever
+// the rate is full and once after the last element, and returns the first rate
+// element. With the parameters of an arkworks PoseidonConfig, this is
+// PoseidonSponge::new, absorb(&preimage), then the first element of
+// squeeze_native_field_elements(1). It is not circomlib's Poseidon, which
+// returns the first capacity element.
+func (p *PoseidonParams) Hash(preimage []fr.Element) (fr.Element, error) {
+	if err := p.check(); err != nil {
+		return fr.Element{}, err
+	}
+	state := make([]fr.Element, p.Rate+p.Capacity)
+	next := 0 // next rate position to absorb into
+	for i := range preimage {
+		if next == p.Rate {
+			p.permute(state)
+			next = 0
+		}
+		state[p.Capacity+next].Add(&state[p.Capacity+next], &preimage[i])
+		next++
+	}
+	p.permute(state)
+	return state[p.Capacity], nil
+}
+
+func (p *PoseidonParams) permute(state []fr.Element) {
+	half := p.FullRounds / 2
+	alpha := new(big.Int).SetUint64(p.Alpha)
+	tmp := make([]fr.Element, len(state))
+	for r := range p.ARK {
+		for i := range state {
+			state[i].Add(&state[i], &p.ARK[r][i])
+		}
+		if r < half || r >= half+p.PartialRounds {
+			for i := range state {
+				state[i].Exp(state[i], alpha)
+			}
+		} else {
+			state[0].Exp(state[0], alpha)
+		}
+		for i := range tmp {
+			tmp[i].SetZero()
+			var t fr.Element
+			for j := range state {
+				t.Mul(&state[j], &p.MDS[i][j])
+				tmp[i].Add(&tmp[i], &t)
+			}
+		}
+		copy(state, tmp)
+	}
+}
+
+// VerifyWithHashedInput verifies the proof and checks that the public input at
+// inputIndex (not counting the constant 1 wire) is the Poseidon hash of
+// preimage. It binds the proof to a known preimage of one of its public inputs.
+func VerifyWithHashedInput(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, preimage []fr.Element, hashParams *PoseidonParams, inputIndex int, opts ...backend.VerifierOption) error {
+	if inputIndex < 0 || inputIndex >= len(publicWitness) {
+		return fmt.Errorf("input index %d out of range, witness has %d public inputs", inputIndex, len(publicWitness))
+	}
+	h, err := hashParams.Hash(preimage)
+	if err != nil {
+		return err
+	}
+	if !h.Equal(&publicWitness[inputIndex]) {
+		return errPreimageMismatch
+	}
+	return Verify(proof, vk, publicWitness, opts...)
+}
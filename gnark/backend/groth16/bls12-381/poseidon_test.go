@@ -0,0 +1,132 @@
+package groth16_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/assert"
+)
+
+// hashedInputCircuit exposes the hash as a public input; the hash itself is
+// not constrained here, VerifyWithHashedInput checks it outside the circuit.
+type hashedInputCircuit struct {
+	Secret frontend.Variable
+	Hash   frontend.Variable `gnark:",public"`
+}
+
+func (c *hashedInputCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.Secret, c.Hash)
+	return nil
+}
+
+func randomPoseidonParams(rate int) *groth16_bls12381.PoseidonParams {
+	params := &groth16_bls12381.PoseidonParams{
+		FullRounds:    8,
+		PartialRounds: 31,
+		Alpha:         17,
+		Rate:          rate,
+		Capacity:      1,
+	}
+	width := params.Rate + params.Capacity
+	params.ARK = make([][]fr.Element, params.FullRounds+params.PartialRounds)
+	for i := range params.ARK {
+		params.ARK[i] = make([]fr.Element, width)
+		for j := range params.ARK[i] {
+			params.ARK[i][j].SetRandom()
+		}
+	}
+	params.MDS = make([][]fr.Element, width)
+	for i := range params.MDS {
+		params.MDS[i] = make([]fr.Element, width)
+		for j := range params.MDS[i] {
+			params.MDS[i][j].SetRandom()
+		}
+	}
+	return params
+}
+
+// smallPoseidonParams are parameters with small constants, so that digests
+// can be computed by hand.
+func smallPoseidonParams(fullRounds, partialRounds, rate int, ark, mds [][]uint64) *groth16_bls12381.PoseidonParams {
+	toElements := func(m [][]uint64) [][]fr.Element {
+		res := make([][]fr.Element, len(m))
+		for i := range m {
+			res[i] = make([]fr.Element, len(m[i]))
+			for j := range m[i] {
+				res[i][j].SetUint64(m[i][j])
+			}
+		}
+		return res
+	}
+	return &groth16_bls12381.PoseidonParams{
+		FullRounds:    fullRounds,
+		PartialRounds: partialRounds,
+		Alpha:         3,
+		MDS:           toElements(mds),
+		ARK:           toElements(ark),
+		Rate:          rate,
+		Capacity:      1,
+	}
+}
+
+// TestPoseidonHash checks digests computed by hand following arkworks
+// PoseidonSponge: the capacity first, then in each round the round constants,
+// the S-box (on the first element only in partial rounds) and
+// new[i] = Σ_j MDS[i][j]·state[j].
+func TestPoseidonHash(t *testing.T) {
+	// x ↦ x⁹ on each element: the identity MDS and no round constants
+	cube2 := smallPoseidonParams(2, 0, 2, [][]uint64{{0, 0, 0}, {0, 0, 0}}, [][]uint64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}})
+	for _, test := range []struct {
+		name     string
+		params   *groth16_bls12381.PoseidonParams
+		preimage []uint64
+		expected uint64
+	}{
+		// [0 2] → ark [0 2] → s-box [0 8] → mds [0 8]
+		//       → ark [1 8] → s-box [1 8] → mds [1 9]
+		//       → ark [1 10] → s-box [1 1000] → mds [1 1001]
+		{"permutation", smallPoseidonParams(2, 1, 1, [][]uint64{{0, 0}, {1, 0}, {0, 1}}, [][]uint64{{1, 0}, {1, 1}}), []uint64{2}, 1001},
+		// [0 2 1] → [0 512 1], a full rate is permuted once
+		{"one block", cube2, []uint64{2, 1}, 512},
+		// [0 1 2] → [0 1 512], absorb 3 → [0 4 512] → [0 262144 512⁹]
+		{"two blocks", cube2, []uint64{1, 2, 3}, 262144},
+		{"empty", cube2, nil, 0},
+	} {
+		preimage := make([]fr.Element, len(test.preimage))
+		for i := range preimage {
+			preimage[i].SetUint64(test.preimage[i])
+		}
+		h, err := test.params.Hash(preimage)
+		assert.NoError(t, err, test.name)
+		var expected fr.Element
+		expected.SetUint64(test.expected)
+		assert.Equal(t, expected, h, test.name)
+	}
+}
+
+func TestVerifyWithHashedInput(t *testing.T) {
+	params := randomPoseidonParams(2)
+	preimage := make([]fr.Element, 5) // more than one rate block
+	for i := range preimage {
+		preimage[i].SetUint64(uint64(i + 1))
+	}
+	h, err := params.Hash(preimage)
+	assert.NoError(t, err)
+
+	ccs, pk, vk := setup(t, &hashedInputCircuit{})
+	public, proof := prove(t, &hashedInputCircuit{Secret: h, Hash: h}, ccs, pk)
+
+	p, v, w := proof.(*groth16_bls12381.Proof), vk.(*groth16_bls12381.VerifyingKey), public.Vector().(fr.Vector)
+	assert.NoError(t, groth16_bls12381.VerifyWithHashedInput(p, v, w, preimage, params, 0))
+
+	wrong := append([]fr.Element{}, preimage...)
+	wrong[4].SetUint64(42)
+	assert.Error(t, groth16_bls12381.VerifyWithHashedInput(p, v, w, wrong, params, 0))
+	assert.Error(t, groth16_bls12381.VerifyWithHashedInput(p, v, w, preimage[:4], params, 0))
+	assert.Error(t, groth16_bls12381.VerifyWithHashedInput(p, v, w, preimage, params, 1))
+
+	params.ARK = params.ARK[1:]
+	assert.Error(t, groth16_bls12381.VerifyWithHashedInput(p, v, w, preimage, params, 0))
+}
@@ -0,0 +1,39 @@
+package groth16
+
+import (
+	"errors"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+var errPreparedOnly = errors.New("verifying key only has e(α, β), not α and β")
+
+// NewPreparedVerifyingKey returns a verifying key made of its prepared form
+// only: e(α, β), -γ, -δ and the IC, as kept by verifiers which dropped α and β
+// once e(α, β) was computed.
+//
+// Verify only needs the prepared form and checks proofs against such a key as
+// against the full one. Operations needing α or β return an error instead:
+// Precompute (e(α, β) can't be recomputed) and serialization, while batch
+// verification falls back to verifying the proofs one by one.
+func NewPreparedVerifyingKey(alphaBeta curve.GT, gammaNeg, deltaNeg curve.G2Affine, ic []curve.G1Affine) (*VerifyingKey, error) {
+	if alphaBeta.IsZero() || alphaBeta.IsOne() {
+		return nil, errors.New("invalid e(α, β)")
+	}
+	if len(ic) == 0 {
+		return nil, errors.New("IC must have at least the point of the constant 1 wire")
+	}
+	vk := new(VerifyingKey)
+	vk.e = alphaBeta
+	vk.G2.gammaNeg, vk.G2.deltaNeg = gammaNeg, deltaNeg
+	vk.G2.Gamma.Neg(&gammaNeg)
+	vk.G2.Delta.Neg(&deltaNeg)
+	vk.G1.K = append([]curve.G1Affine{}, ic...)
+	vk.PublicAndCommitmentCommitted = [][]int{}
+	return vk, nil
+}
+
+// preparedOnly reports whether vk lacks α and β, see NewPreparedVerifyingKey.
+func (vk *VerifyingKey) preparedOnly() bool {
+	return vk.G1.Alpha.IsInfinity() && vk.G2.Beta.IsInfinity()
+}
@@ -0,0 +1,38 @@
+package groth16
+
+import (
+	"bytes"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPrepared(t *testing.T) {
+	proof, vk, publicWitness := proofFixture(t)
+
+	prepared, err := NewPreparedVerifyingKey(vk.e, vk.G2.gammaNeg, vk.G2.deltaNeg, vk.G1.K)
+	require.NoError(t, err)
+	require.True(t, prepared.preparedOnly())
+	require.False(t, vk.preparedOnly())
+
+	require.NoError(t, Verify(proof, prepared, publicWitness))
+	require.Equal(t, vk.NbPublicWitness(), prepared.NbPublicWitness())
+	task := Task{Proof: proof, VerifyingKey: prepared, PublicWitness: publicWitness}
+	require.Equal(t, []error{nil, nil}, VerifySmartBatch([]Task{task, task}))
+
+	wrong := append(fr.Vector{}, publicWitness...)
+	wrong[0].SetUint64(42)
+	require.ErrorIs(t, Verify(proof, prepared, wrong), errPairingCheckFailed)
+
+	// α and β can't be recovered from e(α, β)
+	require.ErrorIs(t, prepared.Precompute(), errPreparedOnly)
+	_, err = prepared.WriteTo(new(bytes.Buffer))
+	require.ErrorIs(t, err, errPreparedOnly)
+
+	_, err = NewPreparedVerifyingKey(curve.GT{}, vk.G2.gammaNeg, vk.G2.deltaNeg, vk.G1.K)
+	require.Error(t, err)
+	_, err = NewPreparedVerifyingKey(vk.e, vk.G2.gammaNeg, vk.G2.deltaNeg, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package groth16
+
+import (
+	"fmt"
+	"io"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// compressedFlag is the zcash serialization flag of compressed points, in the
+// most significant bit of the first byte.
+const compressedFlag = 0b1000_0000
+
+// QuickReject reads the points of an arkworks proof (Ar, Bs, Krs), compressed
+// or not, one at a time from r and checks that each is on the curve and in the
+// correct subgroup. It returns on the first invalid point, without reading the
+// rest of the proof, which makes it a cheap filter for hostile input before
+// full parsing and verification.
+//
+// A nil error doesn't mean the proof is valid, only that its points are.
+func QuickReject(r io.Reader) error {
+	var buf [curve.SizeOfG2AffineUncompressed]byte
+	read := func(name string, compressedSize int) ([]byte, error) {
+		if _, err := io.ReadFull(r, buf[:1]); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		size := 2 * compressedSize
+		if buf[0]&compressedFlag != 0 {
+			size = compressedSize
+		}
+		if _, err := io.ReadFull(r, buf[1:size]); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return buf[:size], nil
+	}
+
+	var g1 curve.G1Affine
+	var g2 curve.G2Affine
+	b, err := read("Ar", curve.SizeOfG1AffineCompressed)
+	if err != nil {
+		return err
+	}
+	if _, err = g1.SetBytes(b); err != nil {
+		return fmt.Errorf("Ar: %w", err)
+	}
+	if b, err = read("Bs", curve.SizeOfG2AffineCompressed); err != nil {
+		return err
+	}
+	if _, err = g2.SetBytes(b); err != nil {
+		return fmt.Errorf("Bs: %w", err)
+	}
+	if b, err = read("Krs", curve.SizeOfG1AffineCompressed); err != nil {
+		return err
+	}
+	if _, err = g1.SetBytes(b); err != nil {
+		return fmt.Errorf("Krs: %w", err)
+	}
+	return nil
+}
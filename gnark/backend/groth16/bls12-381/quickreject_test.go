@@ -0,0 +1,54 @@
+package groth16
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/stretchr/testify/require"
+)
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestQuickReject(t *testing.T) {
+	points, _ := randomTerms(t, 2)
+	_, _, _, g2 := curve.Generators()
+	bad1, _ := outsideSubgroup(t)
+
+	var valid bytes.Buffer
+	ar, bs, krs := points[0].RawBytes(), g2.Bytes(), points[1].Bytes()
+	valid.Write(ar[:])
+	valid.Write(bs[:])
+	valid.Write(krs[:])
+	r := &countingReader{r: bytes.NewReader(valid.Bytes())}
+	require.NoError(t, QuickReject(r))
+	require.Equal(t, valid.Len(), r.n)
+
+	// Ar is not in the subgroup: the rest of the proof is not read
+	var invalid bytes.Buffer
+	bad := bad1.RawBytes()
+	invalid.Write(bad[:])
+	invalid.Write(bs[:])
+	invalid.Write(krs[:])
+	r = &countingReader{r: bytes.NewReader(invalid.Bytes())}
+	require.ErrorContains(t, QuickReject(r), "Ar")
+	require.Equal(t, curve.SizeOfG1AffineUncompressed, r.n)
+
+	// garbage x coordinate, compressed
+	garbage := bytes.Repeat([]byte{0xff}, curve.SizeOfG1AffineCompressed)
+	garbage[0] = compressedFlag
+	r = &countingReader{r: io.MultiReader(bytes.NewReader(garbage), bytes.NewReader(bs[:]))}
+	require.Error(t, QuickReject(r))
+	require.Equal(t, curve.SizeOfG1AffineCompressed, r.n)
+}
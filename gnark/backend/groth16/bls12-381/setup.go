@@ -12,8 +12,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Code generated by gnark DO NOT EDIT
-
 package groth16
 
 import (
@@ -79,6 +77,12 @@ type VerifyingKey struct {
 
 	CommitmentKey                pedersen.VerifyingKey
 	PublicAndCommitmentCommitted [][]int // indexes of public/commitment committed variables
+
+	inputLabels []string // optional, see InputLabels
+	lazyIC      *lazyIC  // optional, see VerifyingKeyWithLazyIC
+	icParts     []icPart // optional, see pairingTerms
+
+	commitmentBasis bool // CommitmentKey was set by WithCommitmentBasis
 }
 
 // Setup constructs the SRS
@@ -339,6 +343,9 @@ func Setup(r1cs *cs.R1CS, pk *ProvingKey, vk *VerifyingKey) error {
 // Precompute sets e, -[δ]₂, -[γ]₂
 // This is meant to be called internally during setup or deserialization.
 func (vk *VerifyingKey) Precompute() error {
+	if vk.preparedOnly() {
+		return errPreparedOnly
+	}
 	var err error
 	vk.e, err = curve.Pair([]curve.G1Affine{vk.G1.Alpha}, []curve.G2Affine{vk.G2.Beta})
 	if err != nil {
@@ -346,6 +353,9 @@ func (vk *VerifyingKey) Precompute() error {
 	}
 	vk.G2.deltaNeg.Neg(&vk.G2.Delta)
 	vk.G2.gammaNeg.Neg(&vk.G2.Gamma)
+	for i := range vk.icParts {
+		vk.icParts[i].gammaNeg.Neg(&vk.icParts[i].gamma)
+	}
 	return nil
 }
 
@@ -606,9 +616,12 @@ func dummyInfinityCount(r1cs *cs.R1CS) (nbZeroesA, nbZeroesB int) {
 // this is used by groth16.Assert to ensure random sampling
 func (vk *VerifyingKey) IsDifferent(_other interface{}) bool {
 	vk2 := _other.(*VerifyingKey)
-	for i := 0; i < len(vk.G1.K); i++ {
-		if !vk.G1.K[i].IsInfinity() {
-			if vk.G1.K[i].Equal(&vk2.G1.K[i]) {
+	if vk.nbIC() != vk2.nbIC() {
+		return true
+	}
+	for i := 0; i < vk.nbIC(); i++ {
+		if p := vk.icPoint(i); !p.IsInfinity() {
+			if p2 := vk2.icPoint(i); p.Equal(&p2) {
 				return false
 			}
 		}
@@ -651,12 +664,12 @@ func (vk *VerifyingKey) CurveID() ecc.ID {
 
 // NbPublicWitness returns the number of elements in the expected public witness
 func (vk *VerifyingKey) NbPublicWitness() int {
-	return (len(vk.G1.K) - 1)
+	return vk.nbIC() - 1
 }
 
 // NbG1 returns the number of G1 elements in the VerifyingKey
 func (vk *VerifyingKey) NbG1() int {
-	return 3 + len(vk.G1.K)
+	return 3 + vk.nbIC()
 }
 
 // NbG2 returns the number of G2 elements in the VerifyingKey
@@ -0,0 +1,64 @@
+package groth16
+
+import (
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/backend"
+)
+
+// isValidWith checks that the points of the proof are in the correct subgroups
+// using the given method.
+func (proof *Proof) isValidWith(method backend.SubgroupCheckMethod) bool {
+	switch method {
+	case backend.SubgroupCheckScalarMul:
+		return g1InSubGroupScalarMul(&proof.Ar) &&
+			g1InSubGroupScalarMul(&proof.Krs) &&
+			g2InSubGroupScalarMul(&proof.Bs)
+	default:
+		return proof.isValid()
+	}
+}
+
+// isOnCurve only checks that the points of the proof are on the curves, see
+// VerifyOptimistic.
+func (proof *Proof) isOnCurve() bool {
+	return proof.Ar.IsOnCurve() && proof.Krs.IsOnCurve() && proof.Bs.IsOnCurve()
+}
+
+// g1InSubGroupScalarMul checks that p is on the curve and that [r]p = 0. The
+// multiplication is a plain double-and-add: the GLV scalar multiplication of
+// gnark-crypto reduces the scalar modulo r and is only correct in the subgroup.
+func g1InSubGroupScalarMul(p *curve.G1Affine) bool {
+	if !p.IsOnCurve() {
+		return false
+	}
+	var base, res curve.G1Jac
+	base.FromAffine(p)
+	res.FromAffine(&curve.G1Affine{})
+	r := fr.Modulus()
+	for i := r.BitLen() - 1; i >= 0; i-- {
+		res.DoubleAssign()
+		if r.Bit(i) == 1 {
+			res.AddAssign(&base)
+		}
+	}
+	return res.Z.IsZero()
+}
+
+// g2InSubGroupScalarMul is the G2 counterpart of g1InSubGroupScalarMul.
+func g2InSubGroupScalarMul(p *curve.G2Affine) bool {
+	if !p.IsOnCurve() {
+		return false
+	}
+	var base, res curve.G2Jac
+	base.FromAffine(p)
+	res.FromAffine(&curve.G2Affine{})
+	r := fr.Modulus()
+	for i := r.BitLen() - 1; i >= 0; i-- {
+		res.DoubleAssign()
+		if r.Bit(i) == 1 {
+			res.AddAssign(&base)
+		}
+	}
+	return res.Z.IsZero()
+}
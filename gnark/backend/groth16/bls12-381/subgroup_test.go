@@ -0,0 +1,98 @@
+package groth16
+
+import (
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/backend"
+	"github.com/stretchr/testify/require"
+)
+
+// outsideSubgroup returns points on the curves which are not in the prime
+// order subgroups. The curve coefficients are recovered from the generators.
+func outsideSubgroup(t testing.TB) (curve.G1Affine, curve.G2Affine) {
+	_, _, g1, g2 := curve.Generators()
+
+	var p1 curve.G1Affine
+	b1 := g1.Y
+	b1.Square(&b1)
+	p1.X.Square(&g1.X)
+	p1.X.Mul(&p1.X, &g1.X)
+	b1.Sub(&b1, &p1.X)
+	for {
+		p1.X.SetRandom()
+		p1.Y.Square(&p1.X)
+		p1.Y.Mul(&p1.Y, &p1.X)
+		p1.Y.Add(&p1.Y, &b1)
+		if p1.Y.Legendre() == 1 {
+			p1.Y.Sqrt(&p1.Y)
+			break
+		}
+	}
+
+	var p2 curve.G2Affine
+	b2 := g2.Y
+	b2.Square(&b2)
+	p2.X.Square(&g2.X)
+	p2.X.Mul(&p2.X, &g2.X)
+	b2.Sub(&b2, &p2.X)
+	for {
+		p2.X.SetRandom()
+		p2.Y.Square(&p2.X)
+		p2.Y.Mul(&p2.Y, &p2.X)
+		p2.Y.Add(&p2.Y, &b2)
+		if p2.Y.Legendre() == 1 {
+			p2.Y.Sqrt(&p2.Y)
+			break
+		}
+	}
+
+	require.True(t, p1.IsOnCurve() && p2.IsOnCurve())
+	require.False(t, p1.IsInSubGroup() || p2.IsInSubGroup())
+	return p1, p2
+}
+
+func TestSubgroupCheckMethods(t *testing.T) {
+	_, _, g1, g2 := curve.Generators()
+	var s fr.Element
+	s.SetRandom()
+	var proof Proof
+	proof.Ar.ScalarMultiplication(&g1, s.BigInt(new(big.Int)))
+	proof.Krs = g1
+	proof.Bs.ScalarMultiplication(&g2, s.BigInt(new(big.Int)))
+
+	methods := []backend.SubgroupCheckMethod{backend.SubgroupCheckEndomorphism, backend.SubgroupCheckScalarMul}
+	for _, m := range methods {
+		require.True(t, proof.isValidWith(m), m.String())
+	}
+
+	bad1, bad2 := outsideSubgroup(t)
+	for _, m := range methods {
+		p := proof
+		p.Krs = bad1
+		require.False(t, p.isValidWith(m), m.String())
+		p = proof
+		p.Bs = bad2
+		require.False(t, p.isValidWith(m), m.String())
+	}
+
+	// the point at infinity is in the subgroups
+	var inf Proof
+	for _, m := range methods {
+		require.True(t, inf.isValidWith(m), m.String())
+	}
+}
+
+func BenchmarkSubgroupCheck(b *testing.B) {
+	_, _, g1, g2 := curve.Generators()
+	proof := Proof{Ar: g1, Krs: g1, Bs: g2}
+	for _, m := range []backend.SubgroupCheckMethod{backend.SubgroupCheckEndomorphism, backend.SubgroupCheckScalarMul} {
+		b.Run(m.String(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				proof.isValidWith(m)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package groth16
+
+import (
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/backend"
+)
+
+// VerifyWithTarget is Verify for protocol variants which fold a constant into
+// the verification equation. Instead of checking that the pairing product
+//
+//	e(Ar, Bs) · e(-α, β) · e(-Σx.[Kvk(t)]1, γ) · e(-Krs, δ)
+//
+// is the identity of GT, it checks that it equals expectedGT. With expectedGT
+// set to one it is equivalent to Verify.
+func VerifyWithTarget(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, expectedGT curve.GT, opts ...backend.VerifierOption) error {
+	return verify(proof, vk, publicWitness, &expectedGT, false, opts...)
+}
@@ -0,0 +1,33 @@
+package groth16
+
+import (
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyWithTarget(t *testing.T) {
+	proof, vk, publicWitness := proofFixture(t)
+
+	var one curve.GT
+	one.SetOne()
+	require.NoError(t, VerifyWithTarget(proof, vk, publicWitness, one))
+
+	// shifting Ar by P multiplies the pairing product by e(P, Bs)
+	var s fr.Element
+	s.SetRandom()
+	_, _, g1, _ := curve.Generators()
+	var p curve.G1Affine
+	p.ScalarMultiplication(&g1, s.BigInt(new(big.Int)))
+	target, err := curve.Pair([]curve.G1Affine{p}, []curve.G2Affine{proof.Bs})
+	require.NoError(t, err)
+
+	shifted := *proof
+	shifted.Ar.Add(&shifted.Ar, &p)
+	require.NoError(t, VerifyWithTarget(&shifted, vk, publicWitness, target))
+	require.ErrorIs(t, Verify(&shifted, vk, publicWitness), errPairingCheckFailed)
+	require.ErrorIs(t, VerifyWithTarget(proof, vk, publicWitness, target), errPairingCheckFailed)
+}
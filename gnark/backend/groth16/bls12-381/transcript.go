@@ -0,0 +1,132 @@
+package groth16
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/backend"
+)
+
+// batchTranscriptDST separates the batch transcript from other uses of
+// SHA-256 and hash-to-field, and versions its layout.
+const batchTranscriptDST = "gnark-groth16-bls12-381-batch-v1"
+
+// VerifyBatchDeterministic is VerifySmartBatch with the coefficients of the
+// linear combinations derived from the tasks (see BatchCoefficients) instead
+// of a random source, so that an auditor holding the same tasks can reproduce
+// every step of the verification.
+//
+// Soundness is kept as in the Fiat–Shamir transform: the coefficients are
+// hashes of the whole batch, so a prover can't know them before fixing its
+// proofs, and changing any proof, key or input of the batch changes them all.
+func VerifyBatchDeterministic(tasks []Task, opts ...backend.VerifierOption) []error {
+	coefficients, err := BatchCoefficients(tasks)
+	if err != nil {
+		errs := make([]error, len(tasks))
+		for i := range errs {
+			errs[i] = fmt.Errorf("batch coefficients: %w", err)
+		}
+		return errs
+	}
+	return verifySmartBatch(tasks, coefficients, opts)
+}
+
+// BatchCoefficients returns the coefficients VerifyBatchDeterministic uses,
+// one per task, in the order of tasks:
+//
+//	ρ₀, …, ρₙ₋₁ = hash_to_field(SHA-256(transcript), batchTranscriptDST, n)
+//
+// with hash_to_field of RFC 9380 over fr (expand_message_xmd with SHA-256),
+// and the transcript made of, integers being 8-byte big-endian and points
+// uncompressed:
+//
+//	batchTranscriptDST ‖ n
+//	for each task:
+//	  SHA-256 of its verifying key (see below)
+//	  Ar ‖ Bs ‖ Krs
+//	  number of commitments ‖ commitments ‖ commitment proof of knowledge
+//	  number of public inputs ‖ public inputs, as 32-byte big-endian words
+//
+// A verifying key is hashed through its prepared form, so that keys with a
+// lazy or split IC, or only prepared, are bound like the others:
+//
+//	e(α, β) ‖ -γ ‖ -δ
+//	number of IC points ‖ IC points
+//	number of IC parts ‖ for each part: start ‖ -γ of the part
+//	commitment key G ‖ G^{-1/σ}
+//	number of commitments ‖ for each: number of indexes ‖ indexes
+//
+// Reordering the tasks changes the transcript, hence every coefficient.
+func BatchCoefficients(tasks []Task) ([]fr.Element, error) {
+	h := sha256.New()
+	h.Write([]byte(batchTranscriptDST))
+	writeUint64(h, len(tasks))
+
+	digests := make(map[*VerifyingKey][]byte)
+	for i := range tasks {
+		vk, proof := tasks[i].VerifyingKey, tasks[i].Proof
+		digest, ok := digests[vk]
+		if !ok {
+			digest = vk.transcriptDigest()
+			digests[vk] = digest
+		}
+		h.Write(digest)
+
+		writePoints(h, proof.Ar.Marshal(), proof.Bs.Marshal(), proof.Krs.Marshal())
+		writeUint64(h, len(proof.Commitments))
+		for j := range proof.Commitments {
+			writePoints(h, proof.Commitments[j].Marshal())
+		}
+		writePoints(h, proof.CommitmentPok.Marshal())
+
+		writeUint64(h, len(tasks[i].PublicWitness))
+		for j := range tasks[i].PublicWitness {
+			b := tasks[i].PublicWitness[j].Bytes()
+			h.Write(b[:])
+		}
+	}
+	return fr.Hash(h.Sum(nil), []byte(batchTranscriptDST), len(tasks))
+}
+
+// transcriptDigest returns the SHA-256 of vk as laid out in BatchCoefficients.
+func (vk *VerifyingKey) transcriptDigest() []byte {
+	h := sha256.New()
+	h.Write(vk.e.Marshal())
+	writePoints(h, vk.G2.gammaNeg.Marshal(), vk.G2.deltaNeg.Marshal())
+
+	n := vk.nbIC()
+	writeUint64(h, n)
+	for i := 0; i < n; i++ {
+		p := vk.icPoint(i)
+		writePoints(h, p.Marshal())
+	}
+	writeUint64(h, len(vk.icParts))
+	for i := range vk.icParts {
+		writeUint64(h, vk.icParts[i].start)
+		writePoints(h, vk.icParts[i].gammaNeg.Marshal())
+	}
+
+	writePoints(h, vk.CommitmentKey.G.Marshal(), vk.CommitmentKey.GRootSigmaNeg.Marshal())
+	writeUint64(h, len(vk.PublicAndCommitmentCommitted))
+	for _, indexes := range vk.PublicAndCommitmentCommitted {
+		writeUint64(h, len(indexes))
+		for _, j := range indexes {
+			writeUint64(h, j)
+		}
+	}
+	return h.Sum(nil)
+}
+
+func writeUint64(h hash.Hash, n int) {
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(n)))
+}
+
+// writePoints writes points marshalled uncompressed.
+func writePoints(h hash.Hash, points ...[]byte) {
+	for _, p := range points {
+		h.Write(p)
+	}
+}
@@ -0,0 +1,81 @@
+package groth16
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBatchDeterministic(t *testing.T) {
+	tasks := batchTasks(t, 3, 2)
+
+	for _, err := range VerifyBatchDeterministic(tasks) {
+		require.NoError(t, err)
+	}
+	coefficients, err := BatchCoefficients(tasks)
+	require.NoError(t, err)
+	require.Len(t, coefficients, len(tasks))
+	require.NoError(t, batchPairingCheck(tasks, []int{0, 1, 2}, coefficients))
+
+	// the same batch yields the same coefficients
+	again, err := BatchCoefficients(append([]Task{}, tasks...))
+	require.NoError(t, err)
+	require.Equal(t, coefficients, again)
+
+	// reordering changes all of them, not only their order
+	reordered := append([]Task{}, tasks...)
+	reordered[0], reordered[1] = reordered[1], reordered[0]
+	other, err := BatchCoefficients(reordered)
+	require.NoError(t, err)
+	for _, c := range other {
+		require.NotContains(t, coefficients, c)
+	}
+
+	// so does changing an input
+	changed := append([]Task{}, tasks...)
+	changed[4].PublicWitness = append(fr.Vector{}, changed[4].PublicWitness...)
+	changed[4].PublicWitness[0].SetUint64(1)
+	other, err = BatchCoefficients(changed)
+	require.NoError(t, err)
+	require.NotEqual(t, coefficients[0], other[0])
+
+	// invalid proofs are caught in the combination
+	bad := *tasks[1].Proof
+	bad.Krs = tasks[2].Proof.Krs
+	tasks[1].Proof = &bad
+	coefficients, err = BatchCoefficients(tasks)
+	require.NoError(t, err)
+	require.Error(t, batchPairingCheck(tasks, []int{0, 1, 2}, coefficients))
+	errs := VerifyBatchDeterministic(tasks)
+	for i, err := range errs {
+		if i == 1 {
+			require.ErrorIs(t, err, errPairingCheckFailed)
+		} else {
+			require.NoError(t, err, "task %d", i)
+		}
+	}
+	require.ErrorIs(t, VerifyBatchDeterministic(changed)[4], errPairingCheckFailed)
+}
+
+func TestBatchCoefficientsKeys(t *testing.T) {
+	proof, vk, publicWitness := proofFixture(t)
+	task := Task{Proof: proof, VerifyingKey: vk, PublicWitness: publicWitness}
+	coefficients, err := BatchCoefficients([]Task{task, task})
+	require.NoError(t, err)
+
+	// keys are hashed by content, not by pointer
+	vkCopy := *vk
+	copied, err := BatchCoefficients([]Task{task, {Proof: proof, VerifyingKey: &vkCopy, PublicWitness: publicWitness}})
+	require.NoError(t, err)
+	require.Equal(t, coefficients, copied)
+
+	// a prepared-only key is bound like the full one, Setup gives keys
+	// without commitments a commitment key all the same
+	prepared, err := NewPreparedVerifyingKey(vk.e, vk.G2.gammaNeg, vk.G2.deltaNeg, vk.G1.K)
+	require.NoError(t, err)
+	prepared.CommitmentKey = vk.CommitmentKey
+	require.Equal(t, vk.transcriptDigest(), prepared.transcriptDigest())
+	prepared.G2.deltaNeg = vk.G2.gammaNeg
+	require.NotEqual(t, vk.transcriptDigest(), prepared.transcriptDigest())
+}
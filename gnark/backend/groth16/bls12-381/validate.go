@@ -0,0 +1,100 @@
+package groth16
+
+import (
+	"errors"
+	"fmt"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// ValidateBatch checks the structure of tasks before a batch verification and
+// returns all the problems found, joined with errors.Join, or nil. For each
+// task it checks that
+//
+//   - the proof, verifying key and public witness are set;
+//   - the number of public inputs matches the IC of the verifying key;
+//   - the proof has one commitment per commitment of the verifying key, and
+//     the committed public inputs exist;
+//   - all points of the proof and verifying key are on the curve and in the
+//     correct subgroup.
+//
+// Tasks are typed to BLS12-381, so the curves of the proof, key and witness
+// always match. A nil error doesn't mean the proofs are valid.
+func ValidateBatch(tasks []Task) error {
+	var errs []error
+	for i := range tasks {
+		for _, err := range validateTask(&tasks[i]) {
+			errs = append(errs, fmt.Errorf("task %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateTask(t *Task) []error {
+	var errs []error
+	if t.Proof == nil {
+		errs = append(errs, errors.New("missing proof"))
+	}
+	if t.VerifyingKey == nil {
+		errs = append(errs, errors.New("missing verifying key"))
+	}
+	if t.PublicWitness == nil {
+		errs = append(errs, errors.New("missing public witness"))
+	}
+
+	if vk := t.VerifyingKey; vk != nil {
+		nbPublic := vk.nbPublicInputs()
+		if nbPublic < 0 {
+			errs = append(errs, fmt.Errorf("verifying key has %d IC points for %d commitments", vk.nbIC(), len(vk.PublicAndCommitmentCommitted)))
+		} else if t.PublicWitness != nil && len(t.PublicWitness) != nbPublic {
+			errs = append(errs, fmt.Errorf("got %d public inputs, verifying key expects %d", len(t.PublicWitness), nbPublic))
+		}
+		for j, committed := range vk.PublicAndCommitmentCommitted {
+			for _, k := range committed {
+				if k < 1 || k > nbPublic {
+					errs = append(errs, fmt.Errorf("commitment %d: committed public input %d out of range", j, k))
+				}
+			}
+		}
+
+		if !vk.G1.Alpha.IsInSubGroup() {
+			errs = append(errs, errors.New("verifying key: alpha is not a valid G1 point"))
+		}
+		for _, p := range []struct {
+			name  string
+			point *curve.G2Affine
+		}{{"beta", &vk.G2.Beta}, {"gamma", &vk.G2.Gamma}, {"delta", &vk.G2.Delta}} {
+			if !p.point.IsInSubGroup() {
+				errs = append(errs, fmt.Errorf("verifying key: %s is not a valid G2 point", p.name))
+			}
+		}
+		for j := 0; j < vk.nbIC(); j++ {
+			if p := vk.icPoint(j); !p.IsInSubGroup() {
+				errs = append(errs, fmt.Errorf("verifying key: IC point %d is not a valid G1 point", j))
+			}
+		}
+		if t.Proof != nil && len(t.Proof.Commitments) != len(vk.PublicAndCommitmentCommitted) {
+			errs = append(errs, fmt.Errorf("proof has %d commitments, verifying key expects %d", len(t.Proof.Commitments), len(vk.PublicAndCommitmentCommitted)))
+		}
+	}
+
+	if proof := t.Proof; proof != nil {
+		for _, p := range []struct {
+			name  string
+			point *curve.G1Affine
+		}{{"Ar", &proof.Ar}, {"Krs", &proof.Krs}} {
+			if !p.point.IsInSubGroup() {
+				errs = append(errs, fmt.Errorf("proof: %s is not a valid G1 point", p.name))
+			}
+		}
+		if !proof.Bs.IsInSubGroup() {
+			errs = append(errs, errors.New("proof: Bs is not a valid G2 point"))
+		}
+		for j := range proof.Commitments {
+			if !proof.Commitments[j].IsInSubGroup() {
+				errs = append(errs, fmt.Errorf("proof: commitment %d is not a valid G1 point", j))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,47 @@
+package groth16
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBatch(t *testing.T) {
+	tasks := batchTasks(t, 4)
+	require.NoError(t, ValidateBatch(tasks))
+
+	g1, g2 := outsideSubgroup(t)
+
+	// task 0: wrong number of inputs
+	tasks[0].PublicWitness = tasks[0].PublicWitness[:1]
+
+	// task 1: two points outside the subgroup
+	proof := *tasks[1].Proof
+	proof.Ar, proof.Bs = g1, g2
+	tasks[1].Proof = &proof
+
+	// task 2: verifying key with an invalid IC point, and an unexpected commitment
+	vk := *tasks[2].VerifyingKey
+	vk.G1.K = append(vk.G1.K[:0:0], vk.G1.K...)
+	vk.G1.K[1] = g1
+	tasks[2].VerifyingKey = &vk
+	proof2 := *tasks[2].Proof
+	proof2.Commitments = append(proof2.Commitments, tasks[2].Proof.Ar)
+	tasks[2].Proof = &proof2
+
+	// task 3: no verifying key
+	tasks[3].VerifyingKey = nil
+
+	err := ValidateBatch(tasks)
+	require.Error(t, err)
+	for _, msg := range []string{
+		"task 0: got 1 public inputs, verifying key expects 2",
+		"task 1: proof: Ar is not a valid G1 point",
+		"task 1: proof: Bs is not a valid G2 point",
+		"task 2: verifying key: IC point 1 is not a valid G1 point",
+		"task 2: proof has 1 commitments, verifying key expects 0",
+		"task 3: missing verifying key",
+	} {
+		require.ErrorContains(t, err, msg)
+	}
+}
@@ -12,14 +12,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Code generated by gnark DO NOT EDIT
-
 package groth16
 
 import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -29,38 +28,55 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/pedersen"
 	"github.com/consensys/gnark-crypto/utils"
 	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16/internal"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/logger"
 )
 
 var (
-	errPairingCheckFailed         = errors.New("pairing doesn't match")
-	errCorrectSubgroupCheckFailed = errors.New("points in the proof are not in the correct subgroup")
+	errPairingCheckFailed         = internal.ErrPairingCheckFailed
+	errCorrectSubgroupCheckFailed = internal.ErrCorrectSubgroupCheckFailed
 )
 
 // Verify verifies a proof with given VerifyingKey and publicWitness
 func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...backend.VerifierOption) error {
+	return verify(proof, vk, publicWitness, nil, false, opts...)
+}
+
+// verify checks the pairing product against expectedGT, or the identity if nil.
+// If onCurveOnly, the proof points are only checked to be on the curves, see
+// VerifyOptimistic.
+func verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, expectedGT *curve.GT, onCurveOnly bool, opts ...backend.VerifierOption) (err error) {
 	opt, err := backend.NewVerifierConfig(opts...)
 	if err != nil {
 		return fmt.Errorf("new verifier config: %w", err)
 	}
+	timer := newStageTimer(opt.Metrics)
+	defer func() { timer.count(err) }()
 	if opt.HashToFieldFn == nil {
 		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
 	}
 
-	nbPublicVars := len(vk.G1.K) - len(vk.PublicAndCommitmentCommitted)
+	nbPublicVars := vk.nbIC() - len(vk.PublicAndCommitmentCommitted)
 	fmt.Printf("nbPublicVars: %d\n", nbPublicVars)
 
 	if len(publicWitness) != nbPublicVars-1 {
-		return fmt.Errorf("invalid witness size, got %d, expected %d (public - ONE_WIRE)", len(publicWitness), len(vk.G1.K)-1)
+		return fmt.Errorf("%w, got %d, expected %d (public - ONE_WIRE)", internal.ErrInvalidWitnessSize, len(publicWitness), vk.nbIC()-1)
 	}
 	log := logger.Logger().With().Str("curve", vk.CurveID().String()).Str("backend", "groth16").Logger()
 	start := time.Now()
 
 	// check that the points in the proof are in the correct subgroup
-	if !proof.isValid() {
+	valid := false
+	if onCurveOnly {
+		valid = proof.isOnCurve()
+	} else {
+		valid = proof.isValidWith(opt.SubgroupCheck)
+	}
+	if !valid {
 		return errCorrectSubgroupCheckFailed
 	}
+	timer.done(backend.MetricsStageSubgroup)
 
 	var doubleML curve.GT
 	chDone := make(chan error, 1)
@@ -103,25 +119,23 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 		return err
 	} else {
 		if err = vk.CommitmentKey.Verify(folded, proof.CommitmentPok); err != nil {
+			if vk.commitmentBasis {
+				return fmt.Errorf("%w: %v", errCommitmentBasisMismatch, err)
+			}
 			return err
 		}
 	}
+	timer.done(backend.MetricsStageCommitments)
 
-	// compute e(Σx.[Kvk(t)]1, -[γ]2)
-	var kSum curve.G1Jac
-	if _, err := kSum.MultiExp(vk.G1.K[1:], publicWitness, ecc.MultiExpConfig{}); err != nil {
+	// compute e(Σx.[Kvk(t)]1, -[γ]2), one term per IC part
+	terms := vk.pairingTerms()
+	icTerms, err := terms.icTerms(vk, publicWitness, proof.Commitments)
+	if err != nil {
 		return err
 	}
-	kSum.AddMixed(&vk.G1.K[0])
-
-	for i := range proof.Commitments {
-		kSum.AddMixed(&proof.Commitments[i])
-	}
-
-	var kSumAff curve.G1Affine
-	kSumAff.FromJacobian(&kSum)
+	timer.done(backend.MetricsStageMSM)
 
-	right, err := curve.MillerLoop([]curve.G1Affine{kSumAff}, []curve.G2Affine{vk.G2.gammaNeg})
+	right, err := curve.MillerLoop(icTerms, terms.gammaNeg)
 	if err != nil {
 		return err
 	}
@@ -132,7 +146,12 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 	}
 
 	right = curve.FinalExponentiation(&right, &doubleML)
-	if !vk.e.Equal(&right) {
+	expected := vk.e
+	if expectedGT != nil {
+		expected.Mul(&expected, expectedGT)
+	}
+	timer.done(backend.MetricsStagePairing)
+	if !expected.Equal(&right) {
 		return errPairingCheckFailed
 	}
 
@@ -140,6 +159,27 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 	return nil
 }
 
+// linearCombination returns Σ scalars[i].points[i]. The common cases of zero or
+// one term (e.g. circuits exposing a single root or digest) skip the
+// multi-exponentiation setup. Points at infinity, such as the IC entries of
+// public inputs the circuit doesn't use, contribute nothing.
+func linearCombination(points []curve.G1Affine, scalars []fr.Element) (curve.G1Jac, error) {
+	var res curve.G1Jac
+	switch len(scalars) {
+	case 0:
+		res.FromAffine(&curve.G1Affine{})
+	case 1:
+		var s big.Int
+		res.FromAffine(&points[0])
+		res.ScalarMultiplication(&res, scalars[0].BigInt(&s))
+	default:
+		if _, err := res.MultiExp(points[:len(scalars)], scalars, ecc.MultiExpConfig{}); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
 // ExportSolidity not implemented for BLS12-381
 func (vk *VerifyingKey) ExportSolidity(w io.Writer) error {
 	return errors.New("not implemented")
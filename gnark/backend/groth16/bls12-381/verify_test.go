@@ -0,0 +1,149 @@
+package groth16
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	cs "github.com/consensys/gnark/constraint/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+func randomTerms(tb testing.TB, n int) ([]curve.G1Affine, []fr.Element) {
+	_, _, g1, _ := curve.Generators()
+	points := make([]curve.G1Affine, n)
+	scalars := make([]fr.Element, n)
+	for i := range points {
+		var s fr.Element
+		if _, err := s.SetRandom(); err != nil {
+			tb.Fatal(err)
+		}
+		points[i].ScalarMultiplication(&g1, s.BigInt(new(big.Int)))
+		if _, err := scalars[i].SetRandom(); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return points, scalars
+}
+
+func TestLinearCombination(t *testing.T) {
+	for n := 0; n <= 3; n++ {
+		points, scalars := randomTerms(t, n)
+
+		var expected curve.G1Jac
+		_, err := expected.MultiExp(points, scalars, ecc.MultiExpConfig{})
+		require.NoError(t, err)
+
+		res, err := linearCombination(points, scalars)
+		require.NoError(t, err)
+
+		var expectedAff, resAff curve.G1Affine
+		expectedAff.FromJacobian(&expected)
+		resAff.FromJacobian(&res)
+		require.Equal(t, expectedAff.Bytes(), resAff.Bytes(), "n=%d", n)
+	}
+}
+
+func TestLinearCombinationInfinity(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 64} {
+		points, scalars := randomTerms(t, n)
+		for i := 0; i < n; i += 2 {
+			points[i] = curve.G1Affine{}
+		}
+
+		var expected curve.G1Jac
+		expected.FromAffine(&curve.G1Affine{})
+		for i := 1; i < n; i += 2 {
+			var term curve.G1Jac
+			term.FromAffine(&points[i])
+			term.ScalarMultiplication(&term, scalars[i].BigInt(new(big.Int)))
+			expected.AddAssign(&term)
+		}
+
+		res, err := linearCombination(points, scalars)
+		require.NoError(t, err)
+
+		var expectedAff, resAff curve.G1Affine
+		expectedAff.FromJacobian(&expected)
+		resAff.FromJacobian(&res)
+		require.True(t, expectedAff.Equal(&resAff), "n=%d", n)
+	}
+}
+
+// unusedInputCircuit has a public input which appears in no constraint, so
+// that its IC entry is the point at infinity.
+type unusedInputCircuit struct {
+	X      frontend.Variable
+	Y      frontend.Variable `gnark:",public"`
+	Unused frontend.Variable `gnark:",public"`
+}
+
+func (c *unusedInputCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+func TestVerifyInfinityIC(t *testing.T) {
+	ccs, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &unusedInputCircuit{}, frontend.IgnoreUnconstrainedInputs())
+	require.NoError(t, err)
+	var (
+		pk ProvingKey
+		vk VerifyingKey
+	)
+	require.NoError(t, Setup(ccs.(*cs.R1CS), &pk, &vk))
+	require.True(t, vk.G1.K[2].IsInfinity())
+
+	w, err := frontend.NewWitness(&unusedInputCircuit{X: 3, Y: 9, Unused: 42}, ecc.BLS12_381.ScalarField())
+	require.NoError(t, err)
+	proof, err := Prove(ccs.(*cs.R1CS), &pk, w)
+	require.NoError(t, err)
+	publicWitness, err := w.Public()
+	require.NoError(t, err)
+	inputs := publicWitness.Vector().(fr.Vector)
+
+	// read back the IC the way an arkworks key is read: the point at infinity
+	// is flagged, but some writers emit all-zero bytes instead
+	for _, encoding := range []func(p *curve.G1Affine) []byte{
+		func(p *curve.G1Affine) []byte { b := p.RawBytes(); return b[:] },
+		func(p *curve.G1Affine) []byte { b := p.Bytes(); return b[:] },
+		func(p *curve.G1Affine) []byte {
+			if p.IsInfinity() {
+				return make([]byte, curve.SizeOfG1AffineUncompressed)
+			}
+			b := p.RawBytes()
+			return b[:]
+		},
+	} {
+		read := vk
+		read.G1.K = make([]curve.G1Affine, len(vk.G1.K))
+		for i := range vk.G1.K {
+			_, err := read.G1.K[i].SetBytes(encoding(&vk.G1.K[i]))
+			require.NoError(t, err)
+		}
+		require.True(t, read.G1.K[2].IsInfinity())
+		require.NoError(t, Verify(proof, &read, inputs))
+	}
+
+	// the unused input doesn't contribute to the verification
+	inputs[1].SetUint64(7)
+	require.NoError(t, Verify(proof, &vk, inputs))
+}
+
+func BenchmarkLinearCombination(b *testing.B) {
+	points, scalars := randomTerms(b, 1)
+	b.Run("single", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = linearCombination(points, scalars)
+		}
+	})
+	b.Run("msm", func(b *testing.B) {
+		var res curve.G1Jac
+		for i := 0; i < b.N; i++ {
+			_, _ = res.MultiExp(points, scalars, ecc.MultiExpConfig{})
+		}
+	})
+}
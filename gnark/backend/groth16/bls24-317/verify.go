@@ -29,21 +29,27 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/pedersen"
 	"github.com/consensys/gnark-crypto/utils"
 	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16/internal"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/logger"
 )
 
 var (
-	errPairingCheckFailed         = errors.New("pairing doesn't match")
-	errCorrectSubgroupCheckFailed = errors.New("points in the proof are not in the correct subgroup")
+	errPairingCheckFailed         = internal.ErrPairingCheckFailed
+	errCorrectSubgroupCheckFailed = internal.ErrCorrectSubgroupCheckFailed
 )
 
 // Verify verifies a proof with given VerifyingKey and publicWitness
-func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...backend.VerifierOption) error {
+func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...backend.VerifierOption) (err error) {
 	opt, err := backend.NewVerifierConfig(opts...)
 	if err != nil {
 		return fmt.Errorf("new verifier config: %w", err)
 	}
+	if opt.SubgroupCheck != backend.SubgroupCheckEndomorphism {
+		return fmt.Errorf("subgroup check method %s is not supported on %s", opt.SubgroupCheck, curve.ID)
+	}
+	timer := newStageTimer(opt.Metrics)
+	defer func() { timer.count(err) }()
 	if opt.HashToFieldFn == nil {
 		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
 	}
@@ -51,7 +57,7 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 	nbPublicVars := len(vk.G1.K) - len(vk.PublicAndCommitmentCommitted)
 
 	if len(publicWitness) != nbPublicVars-1 {
-		return fmt.Errorf("invalid witness size, got %d, expected %d (public - ONE_WIRE)", len(publicWitness), len(vk.G1.K)-1)
+		return fmt.Errorf("%w, got %d, expected %d (public - ONE_WIRE)", internal.ErrInvalidWitnessSize, len(publicWitness), len(vk.G1.K)-1)
 	}
 	log := logger.Logger().With().Str("curve", vk.CurveID().String()).Str("backend", "groth16").Logger()
 	start := time.Now()
@@ -60,6 +66,7 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 	if !proof.isValid() {
 		return errCorrectSubgroupCheckFailed
 	}
+	timer.done(backend.MetricsStageSubgroup)
 
 	var doubleML curve.GT
 	chDone := make(chan error, 1)
@@ -105,6 +112,7 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 			return err
 		}
 	}
+	timer.done(backend.MetricsStageCommitments)
 
 	// compute e(Σx.[Kvk(t)]1, -[γ]2)
 	var kSum curve.G1Jac
@@ -119,6 +127,7 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 
 	var kSumAff curve.G1Affine
 	kSumAff.FromJacobian(&kSum)
+	timer.done(backend.MetricsStageMSM)
 
 	right, err := curve.MillerLoop([]curve.G1Affine{kSumAff}, []curve.G2Affine{vk.G2.gammaNeg})
 	if err != nil {
@@ -131,6 +140,7 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 	}
 
 	right = curve.FinalExponentiation(&right, &doubleML)
+	timer.done(backend.MetricsStagePairing)
 	if !vk.e.Equal(&right) {
 		return errPairingCheckFailed
 	}
@@ -0,0 +1,54 @@
+package groth16_test
+
+import (
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProofFromAffine(t *testing.T) {
+	ccs, pk, vk := setup(t, &oneSecretOnePublicCommittedCircuit{})
+	public, proof := prove(t, &oneSecretOnePublicCommittedCircuit{One: 1, Two: 2}, ccs, pk)
+	p, v, inputs := proof.(*groth16_bn254.Proof), vk.(*groth16_bn254.VerifyingKey), public.Vector().(fr.Vector)
+	assert.Len(t, p.Commitments, 1)
+
+	built, err := groth16_bn254.ProofFromAffineWithCommitments(p.Ar, p.Bs, p.Krs, p.Commitments, p.CommitmentPok)
+	assert.NoError(t, err)
+	assert.Equal(t, p, built)
+	assert.NoError(t, groth16_bn254.Verify(built, v, inputs))
+
+	// the commitments without their proof of knowledge
+	built, err = groth16_bn254.ProofFromAffineWithCommitments(p.Ar, p.Bs, p.Krs, p.Commitments, curve.G1Affine{})
+	assert.NoError(t, err)
+	assert.Error(t, groth16_bn254.Verify(built, v, inputs))
+
+	// a point off the curve
+	var off curve.G1Affine
+	off.X.SetOne()
+	off.Y.SetOne()
+	_, err = groth16_bn254.ProofFromAffine(p.Ar, p.Bs, off)
+	assert.ErrorContains(t, err, "c: ")
+
+	// BN254 G1 has no cofactor, but G2 does: a point of E'(Fp²) outside G2,
+	// y² = x³ + 3/(9+u)
+	var bTwist, y2 curve.E2
+	bTwist.A0.SetUint64(9)
+	bTwist.A1.SetOne()
+	bTwist.Inverse(&bTwist).MulByElement(&bTwist, new(fp.Element).SetUint64(3))
+	var b curve.G2Affine
+	for {
+		b.X.A0.Add(&b.X.A0, new(fp.Element).SetOne())
+		y2.Square(&b.X).Mul(&y2, &b.X).Add(&y2, &bTwist)
+		if y2.Legendre() == 1 {
+			b.Y.Sqrt(&y2)
+			break
+		}
+	}
+	assert.True(t, b.IsOnCurve())
+	_, err = groth16_bn254.ProofFromAffineWithCommitments(p.Ar, b, p.Krs, p.Commitments, p.CommitmentPok)
+	assert.ErrorContains(t, err, "b: ")
+}
@@ -0,0 +1,119 @@
+package groth16
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend"
+	"golang.org/x/crypto/sha3"
+)
+
+// VerificationHintSize is the size of a verification hint.
+const VerificationHintSize = 3 * 32
+
+var (
+	errHintInputsMismatch = errors.New("verification hint is for other public inputs")
+	errHintCommitments    = errors.New("verification hints don't support commitments")
+)
+
+// VerificationHint verifies the proof and returns a hint letting a chain
+// recheck it without the multi-scalar multiplication over the public inputs.
+// The hint is made of 32-byte big-endian words, in the EVM precompile format:
+//
+//	[0:32]  L.X
+//	[32:64] L.Y  with L = K₀ + Σ xᵢ·Kᵢ, the public input point
+//	[64:96] keccak256(x₀ ‖ … ‖ xₙ₋₁), each public input as a 32-byte word
+//
+// Given the hint, the chain checks (see VerifyWithHint) that
+//
+//  1. the digest matches the public inputs it holds;
+//  2. L, Ar and Krs are on G1 and Bs on G2, which the precompiles enforce;
+//  3. e(Ar, Bs) = e(α, β)·e(L, γ)·e(Krs, δ), four pairings.
+//
+// These checks don't establish that L is the public input point of the inputs
+// in the digest: a prover able to choose L can make any proof pass. The chain
+// must still hold L to its definition, either by recomputing it when the hint
+// is challenged, as in optimistic rollups where anyone can dispute a posted L
+// by running the multi-scalar multiplication on chain, or by other means. The
+// hint only moves the multi-scalar multiplication out of the happy path.
+//
+// Verifying keys with commitments aren't supported.
+func VerificationHint(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...backend.VerifierOption) ([]byte, error) {
+	if len(vk.PublicAndCommitmentCommitted) != 0 {
+		return nil, errHintCommitments
+	}
+	if err := Verify(proof, vk, publicWitness, opts...); err != nil {
+		return nil, err
+	}
+
+	var l curve.G1Jac
+	if _, err := l.MultiExp(vk.G1.K[1:], publicWitness, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+	l.AddMixed(&vk.G1.K[0])
+	var lAff curve.G1Affine
+	lAff.FromJacobian(&l)
+
+	hint := make([]byte, 0, VerificationHintSize)
+	hint = append(hint, fpWord(&lAff.X)...)
+	hint = append(hint, fpWord(&lAff.Y)...)
+	return append(hint, inputsDigest(publicWitness)...), nil
+}
+
+// VerifyWithHint performs the checks a chain runs on a verification hint, as
+// described in VerificationHint. It doesn't check that the hinted point is
+// the public input point.
+func VerifyWithHint(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, hint []byte) error {
+	if len(hint) != VerificationHintSize {
+		return errors.New("invalid verification hint size")
+	}
+	if !bytes.Equal(hint[64:], inputsDigest(publicWitness)) {
+		return errHintInputsMismatch
+	}
+
+	var l curve.G1Affine
+	for i, c := range []*fp.Element{&l.X, &l.Y} {
+		v := new(big.Int).SetBytes(hint[i*32 : (i+1)*32])
+		if v.Cmp(fp.Modulus()) >= 0 {
+			return errors.New("hinted point coordinate is not reduced")
+		}
+		c.SetBigInt(v)
+	}
+	if !l.IsInSubGroup() || !proof.isValid() {
+		return errCorrectSubgroupCheckFailed
+	}
+
+	var betaNeg curve.G2Affine
+	betaNeg.Neg(&vk.G2.Beta)
+	ok, err := curve.PairingCheck(
+		[]curve.G1Affine{proof.Ar, vk.G1.Alpha, l, proof.Krs},
+		[]curve.G2Affine{proof.Bs, betaNeg, vk.G2.gammaNeg, vk.G2.deltaNeg},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errPairingCheckFailed
+	}
+	return nil
+}
+
+// inputsDigest returns keccak256 of the public inputs as 32-byte big-endian
+// words, abi.encodePacked of a uint256[] in Solidity.
+func inputsDigest(publicWitness fr.Vector) []byte {
+	h := sha3.NewLegacyKeccak256()
+	var v big.Int
+	for i := range publicWitness {
+		h.Write(publicWitness[i].BigInt(&v).FillBytes(make([]byte, 32)))
+	}
+	return h.Sum(nil)
+}
+
+func fpWord(e *fp.Element) []byte {
+	return e.BigInt(new(big.Int)).FillBytes(make([]byte, 32))
+}
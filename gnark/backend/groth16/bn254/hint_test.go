@@ -0,0 +1,47 @@
+package groth16_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/assert"
+)
+
+type hintCircuit struct {
+	X    frontend.Variable
+	Y, Z frontend.Variable `gnark:",public"`
+}
+
+func (c *hintCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	api.AssertIsEqual(api.Add(c.X, c.Y), c.Z)
+	return nil
+}
+
+func TestVerificationHint(t *testing.T) {
+	ccs, pk, vk := setup(t, &hintCircuit{})
+	public, proof := prove(t, &hintCircuit{X: 3, Y: 9, Z: 12}, ccs, pk)
+	p, v, inputs := proof.(*groth16_bn254.Proof), vk.(*groth16_bn254.VerifyingKey), public.Vector().(fr.Vector)
+
+	hint, err := groth16_bn254.VerificationHint(p, v, inputs)
+	assert.NoError(t, err)
+	assert.Len(t, hint, groth16_bn254.VerificationHintSize)
+	assert.NoError(t, groth16_bn254.VerifyWithHint(p, v, inputs, hint))
+
+	// the digest binds the inputs
+	other := fr.Vector{inputs[0], inputs[1]}
+	other[1].SetUint64(13)
+	assert.ErrorContains(t, groth16_bn254.VerifyWithHint(p, v, other, hint), "other public inputs")
+
+	// a wrong point fails the pairing check
+	bad := append([]byte(nil), hint...)
+	copy(bad[:64], make([]byte, 64))
+	bad[31], bad[63] = 1, 2 // the generator of G1
+	assert.ErrorContains(t, groth16_bn254.VerifyWithHint(p, v, inputs, bad), "pairing")
+
+	// no hint for an invalid proof
+	_, err = groth16_bn254.VerificationHint(p, v, other)
+	assert.Error(t, err)
+}
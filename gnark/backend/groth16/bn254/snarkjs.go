@@ -0,0 +1,117 @@
+package groth16
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// ReadSnarkjsCalldata parses the output of snarkjs generateSolidityCalldata
+// (snarkjs zkey export soliditycalldata):
+//
+//	["a.x","a.y"],[["b.x.A1","b.x.A0"],["b.y.A1","b.y.A0"]],["c.x","c.y"],["in0",...]
+//
+// where every value is a 0x-prefixed hex uint256. As expected by the EVM pairing
+// precompile, the components of the Fp2 coordinates of B are in (A1, A0) order.
+//
+// It returns the proof and the public inputs, without the constant 1 wire.
+func ReadSnarkjsCalldata(text string) (*Proof, []fr.Element, error) {
+	var calldata []json.RawMessage
+	if err := json.Unmarshal([]byte("["+strings.TrimSpace(text)+"]"), &calldata); err != nil {
+		return nil, nil, fmt.Errorf("invalid calldata: %w", err)
+	}
+	if len(calldata) != 4 {
+		return nil, nil, fmt.Errorf("invalid calldata: expected 4 arrays (a, b, c, inputs), got %d", len(calldata))
+	}
+
+	var (
+		a, c   [2]string
+		b      [2][2]string
+		inputs []string
+	)
+	for i, dst := range []any{&a, &b, &c, &inputs} {
+		if err := json.Unmarshal(calldata[i], dst); err != nil {
+			return nil, nil, fmt.Errorf("invalid calldata: %w", err)
+		}
+	}
+
+	// a, b and c are laid out as the words of the Solidity verifier proof
+	var words [8]*big.Int
+	labels := [8]string{"a.x", "a.y", "b.x.A1", "b.x.A0", "b.y.A1", "b.y.A0", "c.x", "c.y"}
+	for i, s := range []string{a[0], a[1], b[0][0], b[0][1], b[1][0], b[1][1], c[0], c[1]} {
+		v, err := parseUint256(s, fp.Modulus())
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", labels[i], err)
+		}
+		words[i] = v
+	}
+	proof, err := ReadProofFromSolidityCalldata(words)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publicInputs := make([]fr.Element, len(inputs))
+	for i := range inputs {
+		v, err := parseUint256(inputs[i], fr.Modulus())
+		if err != nil {
+			return nil, nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		publicInputs[i].SetBigInt(v)
+	}
+
+	return proof, publicInputs, nil
+}
+
+// ReadProofFromSolidityCalldata reads a proof from the uint256 words the
+// Solidity verifier takes, as written by MarshalSolidity:
+//
+//	[Ar.X, Ar.Y, Bs.X.A1, Bs.X.A0, Bs.Y.A1, Bs.Y.A0, Krs.X, Krs.Y]
+//
+// The components of the Fp2 coordinates of Bs are in the (A1, A0) order of the
+// EVM pairing precompile. Proofs with commitments are not supported. The points
+// are checked to be on the curve; Verify checks the subgroups.
+func ReadProofFromSolidityCalldata(words [8]*big.Int) (*Proof, error) {
+	var proof Proof
+	for i, dst := range []*fp.Element{
+		&proof.Ar.X, &proof.Ar.Y,
+		&proof.Bs.X.A1, &proof.Bs.X.A0, &proof.Bs.Y.A1, &proof.Bs.Y.A0,
+		&proof.Krs.X, &proof.Krs.Y,
+	} {
+		if words[i] == nil || words[i].Sign() < 0 || words[i].Cmp(fp.Modulus()) >= 0 {
+			return nil, fmt.Errorf("word %d is not a base field element", i)
+		}
+		dst.SetBigInt(words[i])
+	}
+	if !proof.Ar.IsOnCurve() {
+		return nil, errors.New("Ar: point is not on the curve")
+	}
+	if !proof.Bs.IsOnCurve() {
+		return nil, errors.New("Bs: point is not on the curve")
+	}
+	if !proof.Krs.IsOnCurve() {
+		return nil, errors.New("Krs: point is not on the curve")
+	}
+	return &proof, nil
+}
+
+// parseUint256 parses a 0x-prefixed hex value and checks it is reduced modulo
+// modulus.
+func parseUint256(s string, modulus *big.Int) (*big.Int, error) {
+	digits, ok := strings.CutPrefix(strings.TrimSpace(s), "0x")
+	if !ok {
+		return nil, fmt.Errorf("%q is not 0x-prefixed", s)
+	}
+	v, ok := new(big.Int).SetString(digits, 16)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a hex value", s)
+	}
+	if v.Cmp(modulus) >= 0 {
+		return nil, fmt.Errorf("%q is not reduced modulo %s", s, modulus)
+	}
+	return v, nil
+}
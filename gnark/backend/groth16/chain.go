@@ -0,0 +1,134 @@
+package groth16
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fr_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	fr_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	fr_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// ErrBrokenLink is returned by VerifyChained when a linked input doesn't match
+// the previous proof. The error is a *LinkError.
+var ErrBrokenLink = errors.New("chained input doesn't match the previous proof")
+
+// VerifiedResult records the public inputs of a verified proof, for the next
+// proof of a chain to link its inputs to.
+type VerifiedResult struct {
+	CurveID ecc.ID
+	// Inputs are the public inputs of the proof, without the constant 1 wire.
+	Inputs []*big.Int
+}
+
+// LinkError reports the first linked input of VerifyChained which doesn't
+// match the previous proof.
+type LinkError struct {
+	Input, Output int      // indexes in the current and previous public inputs
+	Got, Expected *big.Int // values of the current input and previous output
+}
+
+func (e *LinkError) Error() string {
+	return fmt.Sprintf("%s: input %d is %s, previous output %d is %s", ErrBrokenLink, e.Input, e.Got, e.Output, e.Expected)
+}
+
+func (e *LinkError) Unwrap() error {
+	return ErrBrokenLink
+}
+
+// VerifyEcho verifies the proof as Verify does and, if it is valid, returns its
+// public inputs.
+func VerifyEcho(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ...backend.VerifierOption) (VerifiedResult, error) {
+	if err := Verify(proof, vk, publicWitness, opts...); err != nil {
+		return VerifiedResult{}, err
+	}
+	inputs, err := witnessValues(publicWitness)
+	if err != nil {
+		return VerifiedResult{}, err
+	}
+	return VerifiedResult{CurveID: vk.CurveID(), Inputs: inputs}, nil
+}
+
+// VerifyChained verifies the next proof of a chain: for each entry i → j of
+// linkMap, the i-th public input of the proof must equal the j-th public input
+// of the previous proof, as echoed by VerifyEcho or VerifyChained. Links are
+// checked in increasing order of i, before the proof, and the first mismatch
+// is returned as a *LinkError. The proof is then verified as by VerifyEcho.
+//
+// Links only carry values from one proof to the next: the circuits must give
+// the linked inputs the same meaning for the chain to be sound.
+func VerifyChained(prev VerifiedResult, proof Proof, vk VerifyingKey, publicWitness witness.Witness, linkMap map[int]int, opts ...backend.VerifierOption) (VerifiedResult, error) {
+	if prev.CurveID != vk.CurveID() {
+		return VerifiedResult{}, fmt.Errorf("previous proof is on %s, verifying key on %s", prev.CurveID, vk.CurveID())
+	}
+	inputs, err := witnessValues(publicWitness)
+	if err != nil {
+		return VerifiedResult{}, err
+	}
+
+	links := make([]int, 0, len(linkMap))
+	for i := range linkMap {
+		links = append(links, i)
+	}
+	sort.Ints(links)
+	for _, i := range links {
+		j := linkMap[i]
+		if i < 0 || i >= len(inputs) {
+			return VerifiedResult{}, fmt.Errorf("link %d -> %d: input out of range, the proof has %d public inputs", i, j, len(inputs))
+		}
+		if j < 0 || j >= len(prev.Inputs) {
+			return VerifiedResult{}, fmt.Errorf("link %d -> %d: output out of range, the previous proof has %d public inputs", i, j, len(prev.Inputs))
+		}
+		if inputs[i].Cmp(prev.Inputs[j]) != 0 {
+			return VerifiedResult{}, &LinkError{Input: i, Output: j, Got: inputs[i], Expected: prev.Inputs[j]}
+		}
+	}
+
+	if err := Verify(proof, vk, publicWitness, opts...); err != nil {
+		return VerifiedResult{}, err
+	}
+	return VerifiedResult{CurveID: vk.CurveID(), Inputs: inputs}, nil
+}
+
+// witnessValues returns the values of a public witness.
+func witnessValues(w witness.Witness) ([]*big.Int, error) {
+	switch v := w.Vector().(type) {
+	case fr_bls12377.Vector:
+		return bigIntValues(v), nil
+	case fr_bls12381.Vector:
+		return bigIntValues(v), nil
+	case fr_bn254.Vector:
+		return bigIntValues(v), nil
+	case fr_bw6761.Vector:
+		return bigIntValues(v), nil
+	case fr_bls24317.Vector:
+		return bigIntValues(v), nil
+	case fr_bls24315.Vector:
+		return bigIntValues(v), nil
+	case fr_bw6633.Vector:
+		return bigIntValues(v), nil
+	default:
+		return nil, witness.ErrInvalidWitness
+	}
+}
+
+// bigIntValues converts field elements to integers.
+func bigIntValues[T any, PT interface {
+	*T
+	BigInt(*big.Int) *big.Int
+}](v []T) []*big.Int {
+	values := make([]*big.Int, len(v))
+	for i := range v {
+		values[i] = PT(&v[i]).BigInt(new(big.Int))
+	}
+	return values
+}
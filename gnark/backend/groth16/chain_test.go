@@ -0,0 +1,69 @@
+package groth16_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func TestVerifyChained(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &snarkjsCircuit{})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+
+	prove := func(x int) (groth16.Proof, witness.Witness) {
+		y := x * x
+		fullWitness, err := frontend.NewWitness(&snarkjsCircuit{X: x, Y: y, Z: x + y}, ecc.BN254.ScalarField())
+		assert.NoError(err)
+		proof, err := groth16.Prove(ccs, pk, fullWitness)
+		assert.NoError(err)
+		publicWitness, err := fullWitness.Public()
+		assert.NoError(err)
+		return proof, publicWitness
+	}
+
+	// Y = 9, Z = 12
+	proof, publicWitness := prove(3)
+	first, err := groth16.VerifyEcho(proof, vk, publicWitness)
+	assert.NoError(err)
+	assert.Equal([]*big.Int{big.NewInt(9), big.NewInt(12)}, first.Inputs)
+
+	// the Y of the next proof must be the Y of the first one
+	link := map[int]int{0: 0}
+	proof, publicWitness = prove(-3)
+	second, err := groth16.VerifyChained(first, proof, vk, publicWitness, link)
+	assert.NoError(err)
+	assert.Equal(int64(9), second.Inputs[0].Int64())
+
+	t.Run("broken link", func(t *testing.T) {
+		assert := test.NewAssert(t)
+		proof, publicWitness := prove(4)
+		_, err := groth16.VerifyChained(second, proof, vk, publicWitness, link)
+		assert.ErrorIs(err, groth16.ErrBrokenLink)
+		var linkErr *groth16.LinkError
+		assert.True(errors.As(err, &linkErr))
+		assert.Equal(0, linkErr.Input)
+		assert.Equal(0, linkErr.Output)
+		assert.Equal(int64(16), linkErr.Got.Int64())
+		assert.Equal(int64(9), linkErr.Expected.Int64())
+
+		// Z of the first proof: 12 ≠ 6
+		proof, publicWitness = prove(-3)
+		_, err = groth16.VerifyChained(first, proof, vk, publicWitness, map[int]int{0: 0, 1: 1})
+		assert.True(errors.As(err, &linkErr))
+		assert.Equal(1, linkErr.Input)
+
+		_, err = groth16.VerifyChained(first, proof, vk, publicWitness, map[int]int{0: 2})
+		assert.Error(err)
+		assert.NotErrorIs(err, groth16.ErrBrokenLink)
+	})
+}
@@ -0,0 +1,56 @@
+package groth16
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/consensys/gnark"
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+const gnarkCryptoModule = "github.com/consensys/gnark-crypto"
+
+// EnvInfo describes the cryptographic implementation verifying proofs, for
+// reproducibility audits.
+type EnvInfo struct {
+	GnarkVersion string
+	// GnarkCryptoVersion is the version of the gnark-crypto module linked in,
+	// that of its replacement if any, or "unknown" if the binary has no module
+	// information.
+	GnarkCryptoVersion string
+	GoVersion          string
+	// Assembly reports whether field arithmetic uses the gnark-crypto amd64
+	// assembly, which the purego build tag disables.
+	Assembly bool
+	// Curves lists the curves Verify supports.
+	Curves []ecc.ID
+}
+
+// VerifyEnvironment returns the EnvInfo of the running binary.
+func VerifyEnvironment() EnvInfo {
+	env := EnvInfo{
+		GnarkVersion:       gnark.Version.String(),
+		GnarkCryptoVersion: "unknown",
+		GoVersion:          runtime.Version(),
+		Assembly:           assemblyBackend,
+		Curves:             gnark.Curves(),
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path != gnarkCryptoModule {
+				continue
+			}
+			env.GnarkCryptoVersion = dep.Version
+			if dep.Replace != nil {
+				env.GnarkCryptoVersion = fmt.Sprintf("%s => %s %s", dep.Version, dep.Replace.Path, dep.Replace.Version)
+			}
+		}
+	}
+	return env
+}
+
+func (env EnvInfo) String() string {
+	return fmt.Sprintf("gnark %s, gnark-crypto %s, %s, assembly: %t, curves: %v",
+		env.GnarkVersion, env.GnarkCryptoVersion, env.GoVersion, env.Assembly, env.Curves)
+}
@@ -0,0 +1,5 @@
+//go:build !amd64 || purego
+
+package groth16
+
+const assemblyBackend = false
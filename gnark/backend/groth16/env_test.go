@@ -0,0 +1,34 @@
+package groth16_test
+
+import (
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/test"
+)
+
+func TestVerifyEnvironment(t *testing.T) {
+	assert := test.NewAssert(t)
+	env := groth16.VerifyEnvironment()
+	assert.NotEmpty(env.GnarkVersion)
+	assert.NotEmpty(env.GnarkCryptoVersion)
+	assert.NotEqual("unknown", env.GnarkCryptoVersion)
+	assert.NotEmpty(env.GoVersion)
+	assert.Equal(gnark.Curves(), env.Curves)
+	assert.Contains(env.String(), env.GnarkCryptoVersion)
+
+	info, ok := debug.ReadBuildInfo()
+	assert.True(ok)
+	var tags []string
+	for _, s := range info.Settings {
+		if s.Key == "-tags" {
+			tags = strings.Split(s.Value, ",")
+		}
+	}
+	assert.Equal(runtime.GOARCH == "amd64" && !slices.Contains(tags, "purego"), env.Assembly)
+}
@@ -0,0 +1,62 @@
+package groth16
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+)
+
+// ReadProofFrame reads from r an arkworks proof in a length-delimited protobuf
+// frame, as written by protobuf's writeDelimitedTo: the size of the proof as a varint,
+// then the proof (see groth16_bls12381.ParseProof for the accepted encodings).
+// It reads exactly the frame, so frames can be read in sequence from a stream,
+// and returns io.EOF if r ends before a new frame.
+//
+// The varint must fit a uint64 and the size can't exceed the largest proof
+// encoding, which bounds the memory a malformed frame can claim. Only
+// BLS12-381 is supported.
+func ReadProofFrame(curveID ecc.ID, r io.Reader) (Proof, error) {
+	if curveID != ecc.BLS12_381 {
+		return nil, fmt.Errorf("reading framed proofs on %s is not supported", curveID)
+	}
+	const maxSize = 2*bls12381.SizeOfG1AffineUncompressed + groth16_bls12381.SizeOfG2AffineEmbedded
+
+	size, err := binary.ReadUvarint(byteReader{r})
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("frame size: %w", err)
+	}
+	if size == 0 || size > maxSize {
+		return nil, fmt.Errorf("invalid frame size %d, expected at most %d bytes", size, maxSize)
+	}
+	data := make([]byte, size)
+	if _, err = io.ReadFull(r, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("frame of %d bytes: %w", size, err)
+	}
+	proof, err := groth16_bls12381.ParseProof(data)
+	if err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// byteReader reads r one byte at a time, not past the bytes it returns.
+type byteReader struct {
+	r io.Reader
+}
+
+func (b byteReader) ReadByte() (byte, error) {
+	var buf [1]byte
+	_, err := io.ReadFull(b.r, buf[:])
+	return buf[0], err
+}
@@ -0,0 +1,56 @@
+package groth16
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadProofFrame(t *testing.T) {
+	uncompressed, err := base64.StdEncoding.DecodeString(arkworksProof)
+	require.NoError(t, err)
+	expected, err := groth16_bls12381.ParseProof(uncompressed)
+	require.NoError(t, err)
+	ar, bs, krs := expected.Ar.Bytes(), expected.Bs.Bytes(), expected.Krs.Bytes()
+	compressed := append(append(ar[:], bs[:]...), krs[:]...)
+
+	frame := func(data []byte) []byte {
+		return append(binary.AppendUvarint(nil, uint64(len(data))), data...)
+	}
+	var stream bytes.Buffer
+	for _, data := range [][]byte{uncompressed, compressed, uncompressed} {
+		stream.Write(frame(data))
+	}
+
+	for i := 0; i < 3; i++ {
+		proof, err := ReadProofFrame(ecc.BLS12_381, &stream)
+		require.NoError(t, err, "frame %d", i)
+		require.Equal(t, expected, proof, "frame %d", i)
+	}
+	_, err = ReadProofFrame(ecc.BLS12_381, &stream)
+	require.ErrorIs(t, err, io.EOF)
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, data := range map[string][]byte{
+			"varint overflow":  bytes.Repeat([]byte{0xff}, 11),
+			"varint truncated": {0x80},
+			"size too large":   binary.AppendUvarint(nil, 1<<40),
+			"empty frame":      {0},
+			"frame truncated":  frame(compressed)[:50],
+			"proof invalid":    frame(uncompressed[:len(uncompressed)-1]),
+		} {
+			proof, err := ReadProofFrame(ecc.BLS12_381, bytes.NewReader(data))
+			require.Error(t, err, name)
+			require.NotErrorIs(t, err, io.EOF, name)
+			require.True(t, proof == nil, name)
+		}
+		_, err := ReadProofFrame(ecc.BN254, bytes.NewReader(frame(compressed)))
+		require.Error(t, err)
+	})
+}
@@ -20,8 +20,10 @@
 package groth16
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"reflect"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend"
@@ -110,51 +112,69 @@ type VerifyingKey interface {
 	IsDifferent(interface{}) bool
 }
 
+// ErrFullWitness is returned by Verify when the witness holds secret values.
+var ErrFullWitness = errors.New("witness has secret values, verify with its public part (witness.Public())")
+
+// errWitnessField is returned by Verify for a witness over another field than
+// the scalar field of the proof's curve.
+var errWitnessField = fmt.Errorf("%w: %w", witness.ErrInvalidWitness, ErrFieldMismatch)
+
 // Verify runs the groth16.Verify algorithm on provided proof with given witness
+//
+// The witness must be public only. A full witness, as returned by
+// frontend.NewWitness, is rejected with ErrFullWitness rather than trimmed to
+// its public part: secret values reaching the verifier usually mean the
+// wrong witness is being passed around, which is better caught early.
 func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ...backend.VerifierOption) error {
+	if err := checkOneWire(vk, publicWitness); err != nil {
+		return err
+	}
+	if err := checkPublicOnly(vk, publicWitness); err != nil {
+		return err
+	}
 
 	switch _proof := proof.(type) {
 	case *groth16_bls12377.Proof:
 		w, ok := publicWitness.Vector().(fr_bls12377.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessField
 		}
 		return groth16_bls12377.Verify(_proof, vk.(*groth16_bls12377.VerifyingKey), w, opts...)
 	case *groth16_bls12381.Proof:
 		w, ok := publicWitness.Vector().(fr_bls12381.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessField
 		}
 		fmt.Printf("w: %v\n", w)
 		return groth16_bls12381.Verify(_proof, vk.(*groth16_bls12381.VerifyingKey), w, opts...)
 	case *groth16_bn254.Proof:
 		w, ok := publicWitness.Vector().(fr_bn254.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessField
 		}
 		return groth16_bn254.Verify(_proof, vk.(*groth16_bn254.VerifyingKey), w, opts...)
 	case *groth16_bw6761.Proof:
 		w, ok := publicWitness.Vector().(fr_bw6761.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessField
 		}
 		return groth16_bw6761.Verify(_proof, vk.(*groth16_bw6761.VerifyingKey), w, opts...)
 	case *groth16_bls24317.Proof:
 		w, ok := publicWitness.Vector().(fr_bls24317.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessField
 		}
 		return groth16_bls24317.Verify(_proof, vk.(*groth16_bls24317.VerifyingKey), w, opts...)
 	case *groth16_bls24315.Proof:
 		w, ok := publicWitness.Vector().(fr_bls24315.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessField
 		}
 		return groth16_bls24315.Verify(_proof, vk.(*groth16_bls24315.VerifyingKey), w, opts...)
 	case *groth16_bw6633.Proof:
 		w, ok := publicWitness.Vector().(fr_bw6633.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessField
 		}
 		return groth16_bw6633.Verify(_proof, vk.(*groth16_bw6633.VerifyingKey), w, opts...)
 	default:
@@ -434,3 +454,13 @@ func NewCS(curveID ecc.ID) constraint.ConstraintSystem {
 	}
 	return r1cs
 }
+
+// checkPublicOnly returns ErrFullWitness if w has more values than vk has
+// public inputs, i.e. secret values. It runs after checkOneWire, which tells
+// the constant 1 wire apart.
+func checkPublicOnly(vk VerifyingKey, w witness.Witness) error {
+	if reflect.ValueOf(w.Vector()).Len() > vk.NbPublicWitness() {
+		return ErrFullWitness
+	}
+	return nil
+}
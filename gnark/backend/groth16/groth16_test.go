@@ -3,12 +3,14 @@ package groth16_test
 import (
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/consensys/gnark"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
@@ -182,3 +184,40 @@ func getCurves() []ecc.ID {
 	}
 	return gnark.Curves()
 }
+
+func TestVerifyFullWitness(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &snarkjsCircuit{})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	fullWitness, err := frontend.NewWitness(&snarkjsCircuit{X: 3, Y: 9, Z: 12}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, fullWitness)
+	assert.NoError(err)
+
+	assert.ErrorIs(groth16.Verify(proof, vk, fullWitness), groth16.ErrFullWitness)
+
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+	assert.NoError(groth16.Verify(proof, vk, publicWitness))
+}
+
+func TestVerifyFieldMismatch(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &snarkjsCircuit{})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	fullWitness, err := frontend.NewWitness(&snarkjsCircuit{X: 3, Y: 9, Z: 12}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, fullWitness)
+	assert.NoError(err)
+
+	// the same values, over the scalar field of BLS12-381
+	publicWitness, err := groth16.PublicWitnessFromDecimalLines(ecc.BLS12_381, strings.NewReader("9\n12"))
+	assert.NoError(err)
+	err = groth16.Verify(proof, vk, publicWitness)
+	assert.ErrorIs(err, groth16.ErrFieldMismatch)
+	assert.ErrorIs(err, witness.ErrInvalidWitness)
+}
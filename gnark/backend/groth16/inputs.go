@@ -0,0 +1,394 @@
+package groth16
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// Endianness is the byte order of a serialized field element.
+type Endianness uint8
+
+const (
+	// LittleEndian is the byte order used by arkworks (CanonicalSerialize).
+	LittleEndian Endianness = iota
+	// BigEndian is the byte order used by gnark, bellman and the EVM.
+	BigEndian
+)
+
+// LimbOrder is the byte order within each 64-bit limb of a serialized field
+// element, the limbs themselves being ordered by the Endianness.
+type LimbOrder uint8
+
+const (
+	// LimbsAsElement limbs have the byte order of the element, so that the
+	// element is fully little-endian or big-endian.
+	LimbsAsElement LimbOrder = iota
+	// LimbsBigEndian limbs are big-endian whatever the element order: with
+	// LittleEndian this is the layout of serializers writing the u64 limbs of
+	// an arkworks BigInt least significant first with to_be_bytes.
+	LimbsBigEndian
+	// LimbsLittleEndian limbs are little-endian whatever the element order.
+	LimbsLittleEndian
+)
+
+// InputForm is the representation of a serialized field element.
+type InputForm uint8
+
+const (
+	// CanonicalForm elements are serialized as the integer they represent.
+	CanonicalForm InputForm = iota
+	// MontgomeryForm elements are serialized as x·R mod r, where R = 2^(8·size)
+	// (the internal representation of arkworks and gnark-crypto).
+	MontgomeryForm
+)
+
+// LengthPrefix is the header preceding a serialized list of field elements.
+type LengthPrefix uint8
+
+const (
+	// PrefixUint64LE is a little-endian uint64 element count, as written by
+	// arkworks for a Vec<F>.
+	PrefixUint64LE LengthPrefix = iota
+	// PrefixUint32BE is a big-endian uint32 element count, as written by
+	// gnark-crypto for a fr.Vector.
+	PrefixUint32BE
+	// NoPrefix means the elements run until the end of the input.
+	NoPrefix
+)
+
+// ErrFieldMismatch is returned when public inputs are over another scalar
+// field than the one of the curve they are used with.
+var ErrFieldMismatch = errors.New("scalar field doesn't match the curve")
+
+var (
+	errInputNotCanonical = errors.New("public input is not reduced modulo the scalar field")
+	errInvalidOneWire    = errors.New("first public input must be the constant 1 wire")
+)
+
+// InputParser decodes serialized public inputs into a public witness.
+//
+// The zero configuration returned by NewInputParser matches arkworks canonical
+// serialization of a Vec<F>: a little-endian uint64 element count followed by
+// strictly reduced little-endian elements, without the constant 1 wire
+// (OneWireImplicit).
+// Options are chainable:
+//
+//	w, err := groth16.NewInputParser(ecc.BN254).
+//		WithEndianness(groth16.BigEndian).
+//		WithLengthPrefix(groth16.NoPrefix).
+//		ParseBytes(data)
+type InputParser struct {
+	curveID ecc.ID
+	strict  bool
+	order   Endianness
+	limbs   LimbOrder
+	oneWire OneWirePolicy
+	form    InputForm
+	prefix  LengthPrefix
+	field   *big.Int
+}
+
+// NewInputParser returns an InputParser for the scalar field of curveID with
+// the arkworks canonical configuration.
+func NewInputParser(curveID ecc.ID) *InputParser {
+	return &InputParser{
+		curveID: curveID,
+		strict:  true,
+		order:   LittleEndian,
+		form:    CanonicalForm,
+		prefix:  PrefixUint64LE,
+	}
+}
+
+// WithStrictCanonical sets whether elements greater or equal to the modulus are
+// rejected (default) or silently reduced.
+func (p *InputParser) WithStrictCanonical(strict bool) *InputParser {
+	p.strict = strict
+	return p
+}
+
+// WithEndianness sets the byte order of each element.
+func (p *InputParser) WithEndianness(order Endianness) *InputParser {
+	p.order = order
+	return p
+}
+
+// WithLimbOrder sets the byte order within each 64-bit limb of an element.
+// Misconfiguring it for mixed layouts typically shows as swapped high and low
+// 64-bit words.
+func (p *InputParser) WithLimbOrder(order LimbOrder) *InputParser {
+	p.limbs = order
+	return p
+}
+
+// WithOneWirePolicy sets whether the serialized inputs start with the constant
+// 1 wire, see OneWirePolicy for the policy of common sources. The default is
+// OneWireImplicit.
+func (p *InputParser) WithOneWirePolicy(policy OneWirePolicy) *InputParser {
+	p.oneWire = policy
+	return p
+}
+
+// WithOneWire sets whether the serialized inputs start with the constant 1
+// wire. If so, the parser checks it and drops it from the witness.
+//
+// Deprecated: use WithOneWirePolicy with OneWirePresent or OneWireImplicit.
+func (p *InputParser) WithOneWire(included bool) *InputParser {
+	if included {
+		return p.WithOneWirePolicy(OneWirePresent)
+	}
+	return p.WithOneWirePolicy(OneWireImplicit)
+}
+
+// WithInputForm sets the representation of each element.
+func (p *InputParser) WithInputForm(form InputForm) *InputParser {
+	p.form = form
+	return p
+}
+
+// WithLengthPrefix sets the header preceding the elements.
+func (p *InputParser) WithLengthPrefix(prefix LengthPrefix) *InputParser {
+	p.prefix = prefix
+	return p
+}
+
+// WithScalarField sets the modulus of the scalar field the inputs were
+// serialized for. Parsing fails with ErrFieldMismatch if it isn't the scalar
+// field of the parser's curve, catching inputs dumped for another curve, e.g.
+// BN254 inputs used with a BLS12-381 verifying key, which would otherwise be
+// read as valid elements and make verification fail.
+func (p *InputParser) WithScalarField(modulus *big.Int) *InputParser {
+	p.field = modulus
+	return p
+}
+
+// ParseBytes is a shorthand for Parse(bytes.NewReader(data)).
+func (p *InputParser) ParseBytes(data []byte) (witness.Witness, error) {
+	return p.Parse(bytes.NewReader(data))
+}
+
+// Parse reads the serialized public inputs from r and returns the
+// corresponding public witness.
+func (p *InputParser) Parse(r io.Reader) (witness.Witness, error) {
+	modulus, err := p.scalarField()
+	if err != nil {
+		return nil, err
+	}
+	size := frSize(modulus)
+
+	var values []*big.Int
+	buf := make([]byte, size)
+	readElement := func(i int) error {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return err
+		}
+		v, err := p.element(buf, modulus)
+		if err != nil {
+			return fmt.Errorf("public input %d: %w", i, err)
+		}
+		values = append(values, v)
+		return nil
+	}
+
+	switch p.prefix {
+	case PrefixUint64LE, PrefixUint32BE:
+		var n uint64
+		if p.prefix == PrefixUint64LE {
+			var header [8]byte
+			if _, err := io.ReadFull(r, header[:]); err != nil {
+				return nil, fmt.Errorf("read length prefix: %w", err)
+			}
+			n = binary.LittleEndian.Uint64(header[:])
+		} else {
+			var header [4]byte
+			if _, err := io.ReadFull(r, header[:]); err != nil {
+				return nil, fmt.Errorf("read length prefix: %w", err)
+			}
+			n = uint64(binary.BigEndian.Uint32(header[:]))
+		}
+		for i := uint64(0); i < n; i++ {
+			if err := readElement(int(i)); err != nil {
+				if err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+				return nil, err
+			}
+		}
+	case NoPrefix:
+		for i := 0; ; i++ {
+			err := readElement(i)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown length prefix %d", p.prefix)
+	}
+
+	return p.publicWitness(values)
+}
+
+// ParseSlices decodes one serialized public input per slice, as found in
+// repeated bytes fields of protobuf messages, and returns the corresponding
+// public witness. The length prefix setting is ignored and every slice must
+// have the exact size of a serialized element.
+func (p *InputParser) ParseSlices(inputs [][]byte) (witness.Witness, error) {
+	modulus, err := p.scalarField()
+	if err != nil {
+		return nil, err
+	}
+	size := frSize(modulus)
+
+	values := make([]*big.Int, len(inputs))
+	for i := range inputs {
+		if len(inputs[i]) != size {
+			return nil, fmt.Errorf("public input %d: expected %d bytes, got %d", i, size, len(inputs[i]))
+		}
+		v, err := p.element(inputs[i], modulus)
+		if err != nil {
+			return nil, fmt.Errorf("public input %d: %w", i, err)
+		}
+		values[i] = v
+	}
+
+	return p.publicWitness(values)
+}
+
+// scalarField returns the scalar field of the parser's curve, checking it
+// against the one set with WithScalarField.
+func (p *InputParser) scalarField() (*big.Int, error) {
+	modulus := p.curveID.ScalarField()
+	if p.field != nil && p.field.Cmp(modulus) != 0 {
+		return nil, fmt.Errorf("%w: inputs are for modulus %s, %s has %s", ErrFieldMismatch, p.field, p.curveID, modulus)
+	}
+	return modulus, nil
+}
+
+// publicWitness applies the one wire policy to the decoded values and returns
+// the public witness.
+func (p *InputParser) publicWitness(values []*big.Int) (witness.Witness, error) {
+	values, err := p.oneWire.apply(values)
+	if err != nil {
+		return nil, err
+	}
+	return newPublicWitness(p.curveID, values)
+}
+
+// element decodes a single serialized field element.
+func (p *InputParser) element(buf []byte, modulus *big.Int) (*big.Int, error) {
+	be := make([]byte, len(buf))
+	copy(be, buf)
+	if (p.limbs == LimbsBigEndian && p.order == LittleEndian) || (p.limbs == LimbsLittleEndian && p.order == BigEndian) {
+		// swap the bytes of each limb to get a fully little or big-endian element
+		for i := 0; i+8 <= len(be); i += 8 {
+			reverse(be[i : i+8])
+		}
+	}
+	if p.order == LittleEndian {
+		reverse(be)
+	}
+	v := new(big.Int).SetBytes(be)
+	if v.Cmp(modulus) >= 0 {
+		if p.strict {
+			return nil, errInputNotCanonical
+		}
+		v.Mod(v, modulus)
+	}
+	if p.form == MontgomeryForm {
+		rInv := new(big.Int).Lsh(big.NewInt(1), uint(8*len(buf)))
+		rInv.ModInverse(rInv, modulus)
+		v.Mul(v, rInv).Mod(v, modulus)
+	}
+	return v, nil
+}
+
+// PublicWitnessFromDecimalLines reads a public witness from r holding one
+// decimal public input per line, without the constant 1 wire
+// (OneWireImplicit):
+//
+//	# merkle root
+//	1234567890
+//
+//	42
+//
+// Surrounding whitespace is trimmed, blank lines and lines starting with # are
+// skipped, and values are reduced modulo the scalar field of curveID.
+func PublicWitnessFromDecimalLines(curveID ecc.ID, r io.Reader) (witness.Witness, error) {
+	modulus := curveID.ScalarField()
+
+	var values []*big.Int
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		v, ok := new(big.Int).SetString(line, 10)
+		if !ok {
+			return nil, fmt.Errorf("line %d: invalid decimal value %q", lineNo, line)
+		}
+		values = append(values, v.Mod(v, modulus))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return newPublicWitness(curveID, values)
+}
+
+// PublicWitnessFromByteSlices returns the public witness made of inputs, one
+// little-endian canonical element per slice, without the constant 1 wire
+// (OneWireImplicit). It is
+// a shorthand for NewInputParser(curveID).ParseSlices(inputs).
+func PublicWitnessFromByteSlices(curveID ecc.ID, inputs [][]byte) (witness.Witness, error) {
+	return NewInputParser(curveID).ParseSlices(inputs)
+}
+
+// newPublicWitness returns a public witness over the scalar field of curveID
+// holding values.
+func newPublicWitness[T any](curveID ecc.ID, values []T) (witness.Witness, error) {
+	w, err := witness.New(curveID.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan any)
+	go func() {
+		for _, v := range values {
+			ch <- v
+		}
+		close(ch)
+	}()
+	if err := w.Fill(len(values), 0, ch); err != nil {
+		// unblock the sender
+		go func() {
+			for range ch {
+			}
+		}()
+		return nil, err
+	}
+	return w, nil
+}
+
+// frSize returns the number of bytes of a serialized element of the field
+// defined by modulus, rounded up to 64-bit limbs.
+func frSize(modulus *big.Int) int {
+	return (modulus.BitLen() + 63) / 64 * 8
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
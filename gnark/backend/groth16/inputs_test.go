@@ -0,0 +1,170 @@
+package groth16
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputParser(t *testing.T) {
+	raw, err := base64.StdEncoding.DecodeString(arkworksInputs)
+	require.NoError(t, err)
+
+	// expected value: the element is serialized little-endian after the uint64 count
+	be := make([]byte, fr.Bytes)
+	copy(be, raw[8:])
+	reverse(be)
+	var expected fr.Element
+	expected.SetBigInt(new(big.Int).SetBytes(be))
+
+	check := func(t *testing.T, p *InputParser, data []byte, want ...fr.Element) {
+		t.Helper()
+		w, err := p.ParseBytes(data)
+		require.NoError(t, err)
+		require.Equal(t, fr.Vector(want), w.Vector().(fr.Vector))
+		public, err := w.Public()
+		require.NoError(t, err)
+		require.Equal(t, len(want), len(public.Vector().(fr.Vector)))
+	}
+
+	t.Run("arkworks default", func(t *testing.T) {
+		check(t, NewInputParser(ecc.BLS12_381), raw, expected)
+	})
+
+	t.Run("big-endian, uint32 prefix", func(t *testing.T) {
+		data := binary.BigEndian.AppendUint32(nil, 1)
+		data = append(data, expected.BigInt(new(big.Int)).FillBytes(make([]byte, fr.Bytes))...)
+		p := NewInputParser(ecc.BLS12_381).WithEndianness(BigEndian).WithLengthPrefix(PrefixUint32BE)
+		check(t, p, data, expected)
+	})
+
+	t.Run("no prefix, one wire", func(t *testing.T) {
+		one := make([]byte, fr.Bytes)
+		one[0] = 1
+		data := append(one, raw[8:]...)
+		check(t, NewInputParser(ecc.BLS12_381).WithLengthPrefix(NoPrefix).WithOneWire(true), data, expected)
+
+		_, err := NewInputParser(ecc.BLS12_381).WithLengthPrefix(NoPrefix).WithOneWire(true).ParseBytes(raw[8:])
+		require.ErrorIs(t, err, errInvalidOneWire)
+	})
+
+	t.Run("montgomery form", func(t *testing.T) {
+		// fr.Element limbs are the Montgomery representation
+		data := binary.LittleEndian.AppendUint64(nil, 1)
+		for _, limb := range expected {
+			data = binary.LittleEndian.AppendUint64(data, limb)
+		}
+		check(t, NewInputParser(ecc.BLS12_381).WithInputForm(MontgomeryForm), data, expected)
+	})
+
+	t.Run("mixed limb order", func(t *testing.T) {
+		// little-endian limbs, each written big-endian
+		data := binary.LittleEndian.AppendUint64(nil, 1)
+		for _, limb := range expected.Bits() {
+			data = binary.BigEndian.AppendUint64(data, limb)
+		}
+		check(t, NewInputParser(ecc.BLS12_381).WithLimbOrder(LimbsBigEndian), data, expected)
+
+		w, err := NewInputParser(ecc.BLS12_381).WithStrictCanonical(false).ParseBytes(data)
+		require.NoError(t, err)
+		require.NotEqual(t, fr.Vector{expected}, w.Vector().(fr.Vector))
+
+		// and the other way around: big-endian limbs, each written little-endian
+		data = binary.BigEndian.AppendUint32(nil, 1)
+		bits := expected.Bits()
+		for i := len(bits) - 1; i >= 0; i-- {
+			data = binary.LittleEndian.AppendUint64(data, bits[i])
+		}
+		p := NewInputParser(ecc.BLS12_381).WithEndianness(BigEndian).WithLengthPrefix(PrefixUint32BE).WithLimbOrder(LimbsLittleEndian)
+		check(t, p, data, expected)
+
+		// limbs matching the element order are the default layouts
+		check(t, NewInputParser(ecc.BLS12_381).WithLimbOrder(LimbsLittleEndian), raw, expected)
+	})
+
+	t.Run("strict canonical", func(t *testing.T) {
+		// q + x, big-endian, is a non-reduced encoding of x
+		nonReduced := new(big.Int).Add(fr.Modulus(), big.NewInt(42))
+		data := append(binary.BigEndian.AppendUint32(nil, 1), nonReduced.FillBytes(make([]byte, fr.Bytes))...)
+
+		p := NewInputParser(ecc.BLS12_381).WithEndianness(BigEndian).WithLengthPrefix(PrefixUint32BE)
+		_, err := p.ParseBytes(data)
+		require.ErrorIs(t, err, errInputNotCanonical)
+
+		var fortyTwo fr.Element
+		fortyTwo.SetUint64(42)
+		check(t, p.WithStrictCanonical(false), data, fortyTwo)
+	})
+
+	t.Run("scalar field", func(t *testing.T) {
+		check(t, NewInputParser(ecc.BLS12_381).WithScalarField(ecc.BLS12_381.ScalarField()), raw, expected)
+
+		_, err := NewInputParser(ecc.BLS12_381).WithScalarField(ecc.BN254.ScalarField()).ParseBytes(raw)
+		require.ErrorIs(t, err, ErrFieldMismatch)
+		_, err = NewInputParser(ecc.BLS12_381).WithScalarField(ecc.BN254.ScalarField()).ParseSlices([][]byte{raw[8:]})
+		require.ErrorIs(t, err, ErrFieldMismatch)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := NewInputParser(ecc.BLS12_381).ParseBytes(raw[:len(raw)-1])
+		require.Error(t, err)
+	})
+}
+
+func TestPublicWitnessFromDecimalLines(t *testing.T) {
+	const input = `# public inputs
+42
+
+  # a value larger than the modulus is reduced
+  52435875175126190479447740508185965837690552500527637822603658699938581184555
+7	
+
+`
+	w, err := PublicWitnessFromDecimalLines(ecc.BLS12_381, strings.NewReader(input))
+	require.NoError(t, err)
+
+	var expected fr.Vector = make([]fr.Element, 3)
+	expected[0].SetUint64(42)
+	expected[1].SetUint64(42)
+	expected[2].SetUint64(7)
+	require.Equal(t, expected, w.Vector().(fr.Vector))
+
+	_, err = PublicWitnessFromDecimalLines(ecc.BLS12_381, strings.NewReader("1\n\n0x2a\n"))
+	require.ErrorContains(t, err, "line 3")
+}
+
+func TestPublicWitnessFromByteSlices(t *testing.T) {
+	le := func(v uint64) []byte {
+		b := new(big.Int).SetUint64(v).FillBytes(make([]byte, fr.Bytes))
+		reverse(b)
+		return b
+	}
+
+	w, err := PublicWitnessFromByteSlices(ecc.BLS12_381, [][]byte{le(42), le(7)})
+	require.NoError(t, err)
+	var expected fr.Vector = make([]fr.Element, 2)
+	expected[0].SetUint64(42)
+	expected[1].SetUint64(7)
+	require.Equal(t, expected, w.Vector().(fr.Vector))
+
+	w, err = PublicWitnessFromByteSlices(ecc.BLS12_381, nil)
+	require.NoError(t, err)
+	require.Len(t, w.Vector().(fr.Vector), 0)
+
+	_, err = PublicWitnessFromByteSlices(ecc.BLS12_381, [][]byte{le(42), le(7)[:31]})
+	require.ErrorContains(t, err, "public input 1: expected 32 bytes, got 31")
+	_, err = PublicWitnessFromByteSlices(ecc.BLS12_381, [][]byte{append(le(42), 0)})
+	require.ErrorContains(t, err, "public input 0: expected 32 bytes, got 33")
+
+	modulus := fr.Modulus().FillBytes(make([]byte, fr.Bytes))
+	reverse(modulus)
+	_, err = PublicWitnessFromByteSlices(ecc.BLS12_381, [][]byte{modulus})
+	require.ErrorIs(t, err, errInputNotCanonical)
+}
@@ -0,0 +1,12 @@
+package internal
+
+import "errors"
+
+// Errors shared by the curve packages, so that they can be told apart
+// whatever the curve.
+var (
+	ErrPairingCheckFailed         = errors.New("pairing doesn't match")
+	ErrCorrectSubgroupCheckFailed = errors.New("points in the proof are not in the correct subgroup")
+	ErrInvalidWitnessSize         = errors.New("invalid witness size")
+	ErrCommitmentBasisMismatch    = errors.New("commitment proof of knowledge doesn't hold for the commitment basis")
+)
@@ -0,0 +1,224 @@
+package groth16
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// VerifyingKeyFetcher fetches the serialized verifying key identified by key,
+// e.g. its hash, from a remote object store, a database...
+type VerifyingKeyFetcher func(ctx context.Context, key string) ([]byte, error)
+
+// ErrVerifyingKeyNotFound is returned by MemoryFetcher for unknown keys.
+var ErrVerifyingKeyNotFound = errors.New("verifying key not found")
+
+// MemoryFetcher returns a VerifyingKeyFetcher serving the serialized verifying
+// keys of vks.
+func MemoryFetcher(vks map[string][]byte) VerifyingKeyFetcher {
+	return func(_ context.Context, key string) ([]byte, error) {
+		b, ok := vks[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrVerifyingKeyNotFound, key)
+		}
+		return b, nil
+	}
+}
+
+// VerifyingKeyLoader fetches verifying keys on demand and keeps the parsed,
+// precomputed keys in a least recently used cache, with an optional time to
+// live. Keys can be pinned to stay cached until a deadline, see PinUntil. It is
+// safe for concurrent use.
+type VerifyingKeyLoader struct {
+	curveID  ecc.ID
+	fetch    VerifyingKeyFetcher
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+
+	lock    sync.Mutex
+	lru     *list.List // of *loaderEntry, most recently used first
+	entries map[string]*list.Element
+	pins    map[string]time.Time
+	stats   LoaderStats
+}
+
+// LoaderStats are the metrics of a VerifyingKeyLoader cache.
+type LoaderStats struct {
+	Size        int    // number of cached keys
+	Evictions   uint64 // keys evicted to keep the cache within its capacity
+	Expirations uint64 // keys dropped as older than the time to live
+}
+
+type loaderEntry struct {
+	key     string
+	vk      VerifyingKey
+	fetched time.Time
+}
+
+// NewVerifyingKeyLoader returns a loader of verifying keys on curveID fetched
+// by fetch, caching up to capacity keys, at least one. The fetched bytes are
+// read with VerifyingKey.ReadFrom, except BLS12-381 keys, read with
+// groth16_bls12381.ParseVerifyingKey along with their input labels.
+func NewVerifyingKeyLoader(curveID ecc.ID, fetch VerifyingKeyFetcher, capacity int) (*VerifyingKeyLoader, error) {
+	if capacity < 1 {
+		return nil, fmt.Errorf("verifying key loader capacity must be at least 1, got %d", capacity)
+	}
+	return &VerifyingKeyLoader{
+		curveID:  curveID,
+		fetch:    fetch,
+		capacity: capacity,
+		now:      time.Now,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+		pins:     make(map[string]time.Time),
+	}, nil
+}
+
+// WithTTL sets how long a cached key is used before being fetched again. Zero,
+// the default, means keys only leave the cache when evicted.
+func (l *VerifyingKeyLoader) WithTTL(ttl time.Duration) *VerifyingKeyLoader {
+	l.ttl = ttl
+	return l
+}
+
+// Load returns the verifying key identified by key, from the cache or fetched
+// and parsed.
+func (l *VerifyingKeyLoader) Load(ctx context.Context, key string) (VerifyingKey, error) {
+	if vk, ok := l.cached(key); ok {
+		return vk, nil
+	}
+
+	b, err := l.fetch(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("fetch verifying key %q: %w", key, err)
+	}
+	vk, err := readVerifyingKey(l.curveID, b)
+	if err != nil {
+		return nil, fmt.Errorf("read verifying key %q: %w", key, err)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if e, ok := l.entries[key]; ok {
+		// fetched concurrently
+		l.lru.Remove(e)
+	}
+	now := l.now()
+	l.prunePins(now)
+	l.entries[key] = l.lru.PushFront(&loaderEntry{key: key, vk: vk, fetched: now})
+
+	// evict the least recently used keys which are not pinned
+	for e := l.lru.Back(); e != nil && l.lru.Len() > l.capacity; {
+		prev := e.Prev()
+		if k := e.Value.(*loaderEntry).key; !l.pinned(k, now) {
+			l.lru.Remove(e)
+			delete(l.entries, k)
+			l.stats.Evictions++
+		}
+		e = prev
+	}
+	return vk, nil
+}
+
+// PinUntil keeps key cached until deadline, for circuits known to be hot: it
+// is neither evicted to make room for other keys nor dropped when older than
+// the time to live before then. key needs not be cached yet, and a deadline in
+// the past unpins it. While pinned keys fill the cache, its capacity is
+// exceeded rather than a pinned key evicted.
+func (l *VerifyingKeyLoader) PinUntil(key string, deadline time.Time) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.pins[key] = deadline
+	l.prunePins(l.now())
+}
+
+// prunePins forgets the pins past at now, including those of keys never
+// loaded. l.lock must be held.
+func (l *VerifyingKeyLoader) prunePins(now time.Time) {
+	for key, deadline := range l.pins {
+		if !now.Before(deadline) {
+			delete(l.pins, key)
+		}
+	}
+}
+
+// pinned reports whether key is pinned at now, forgetting past pins. l.lock
+// must be held.
+func (l *VerifyingKeyLoader) pinned(key string, now time.Time) bool {
+	deadline, ok := l.pins[key]
+	if ok && !now.Before(deadline) {
+		delete(l.pins, key)
+		return false
+	}
+	return ok
+}
+
+func (l *VerifyingKeyLoader) cached(key string) (VerifyingKey, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	e, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*loaderEntry)
+	if now := l.now(); l.ttl > 0 && now.Sub(entry.fetched) >= l.ttl && !l.pinned(key, now) {
+		l.lru.Remove(e)
+		delete(l.entries, key)
+		l.stats.Expirations++
+		return nil, false
+	}
+	l.lru.MoveToFront(e)
+	return entry.vk, true
+}
+
+// Len returns the number of cached verifying keys.
+func (l *VerifyingKeyLoader) Len() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.lru.Len()
+}
+
+// Stats returns the metrics of the cache, e.g. to export them as gauges and
+// counters.
+func (l *VerifyingKeyLoader) Stats() LoaderStats {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	stats := l.stats
+	stats.Size = l.lru.Len()
+	return stats
+}
+
+// Verify verifies proof against the verifying key identified by key.
+func (l *VerifyingKeyLoader) Verify(ctx context.Context, key string, proof Proof, publicWitness witness.Witness, opts ...backend.VerifierOption) error {
+	vk, err := l.Load(ctx, key)
+	if err != nil {
+		return err
+	}
+	return Verify(proof, vk, publicWitness, opts...)
+}
+
+// readVerifyingKey reads a verifying key on curveID from b.
+func readVerifyingKey(curveID ecc.ID, b []byte) (VerifyingKey, error) {
+	if curveID == ecc.BLS12_381 {
+		vk, err := groth16_bls12381.ParseVerifyingKey(b)
+		if err != nil {
+			return nil, err
+		}
+		return vk, nil
+	}
+	vk := NewVerifyingKey(curveID)
+	if _, err := vk.ReadFrom(bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+	return vk, nil
+}
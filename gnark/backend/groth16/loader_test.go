@@ -0,0 +1,173 @@
+package groth16
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+type loaderCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *loaderCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+func TestVerifyingKeyLoader(t *testing.T) {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &loaderCircuit{})
+	require.NoError(t, err)
+
+	vks := make(map[string][]byte)
+	var pk ProvingKey
+	for _, key := range []string{"a", "b", "c"} {
+		var vk VerifyingKey
+		pk, vk, err = Setup(ccs)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		_, err = vk.WriteTo(&buf)
+		require.NoError(t, err)
+		vks[key] = buf.Bytes()
+	}
+
+	fetches := make(map[string]int)
+	memory := MemoryFetcher(vks)
+	fetch := func(ctx context.Context, key string) ([]byte, error) {
+		fetches[key]++
+		return memory(ctx, key)
+	}
+	now := time.Unix(0, 0)
+	loader, err := NewVerifyingKeyLoader(ecc.BN254, fetch, 2)
+	require.NoError(t, err)
+	loader.WithTTL(time.Minute)
+	loader.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// pk belongs to key "c"
+	w, err := frontend.NewWitness(&loaderCircuit{X: 3, Y: 9}, ecc.BN254.ScalarField())
+	require.NoError(t, err)
+	proof, err := Prove(ccs, pk, w)
+	require.NoError(t, err)
+	publicWitness, err := w.Public()
+	require.NoError(t, err)
+	require.NoError(t, loader.Verify(ctx, "c", proof, publicWitness))
+	require.Error(t, loader.Verify(ctx, "a", proof, publicWitness))
+	require.Equal(t, map[string]int{"a": 1, "c": 1}, fetches)
+
+	// hit
+	_, err = loader.Load(ctx, "c")
+	require.NoError(t, err)
+	require.Equal(t, 1, fetches["c"])
+
+	// "a" is the least recently used and is evicted by "b"
+	_, err = loader.Load(ctx, "b")
+	require.NoError(t, err)
+	require.Equal(t, 2, loader.Len())
+	_, err = loader.Load(ctx, "c")
+	require.NoError(t, err)
+	require.Equal(t, 1, fetches["c"])
+	_, err = loader.Load(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, 2, fetches["a"])
+
+	// expired entries are fetched again
+	now = now.Add(time.Minute)
+	_, err = loader.Load(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, 3, fetches["a"])
+
+	_, err = loader.Load(ctx, "unknown")
+	require.ErrorIs(t, err, ErrVerifyingKeyNotFound)
+	require.Equal(t, 2, loader.Len())
+
+	for _, capacity := range []int{0, -1} {
+		_, err = NewVerifyingKeyLoader(ecc.BN254, fetch, capacity)
+		require.Error(t, err, "capacity %d", capacity)
+	}
+}
+
+func TestVerifyingKeyLoaderPinned(t *testing.T) {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &loaderCircuit{})
+	require.NoError(t, err)
+	_, vk, err := Setup(ccs)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = vk.WriteTo(&buf)
+	require.NoError(t, err)
+	keys := []string{"hot", "a", "b", "c", "d"}
+	vks := make(map[string][]byte)
+	for _, key := range keys {
+		vks[key] = buf.Bytes()
+	}
+
+	fetches := make(map[string]int)
+	memory := MemoryFetcher(vks)
+	fetch := func(ctx context.Context, key string) ([]byte, error) {
+		fetches[key]++
+		return memory(ctx, key)
+	}
+	now := time.Unix(0, 0)
+	loader, err := NewVerifyingKeyLoader(ecc.BN254, fetch, 2)
+	require.NoError(t, err)
+	loader.WithTTL(time.Minute)
+	loader.now = func() time.Time { return now }
+	ctx := context.Background()
+	load := func(key string) {
+		_, err := loader.Load(ctx, key)
+		require.NoError(t, err)
+	}
+
+	loader.PinUntil("hot", now.Add(time.Hour))
+	// "hot" is the least recently used key all along but stays cached
+	for _, key := range keys {
+		load(key)
+	}
+	require.Equal(t, LoaderStats{Size: 2, Evictions: 3}, loader.Stats())
+	load("hot")
+	require.Equal(t, 1, fetches["hot"])
+	load("a")
+	require.Equal(t, 2, fetches["a"])
+
+	// nor does it expire before the deadline
+	now = now.Add(2 * time.Minute)
+	load("hot")
+	load("d")
+	require.Equal(t, 1, fetches["hot"])
+	require.Equal(t, 2, fetches["d"])
+	require.Equal(t, LoaderStats{Size: 2, Evictions: 5}, loader.Stats())
+
+	// after the deadline it is an ordinary key
+	now = now.Add(time.Hour)
+	load("hot")
+	require.Equal(t, 2, fetches["hot"])
+	require.Equal(t, uint64(1), loader.Stats().Expirations)
+	load("b")
+	load("c")
+	load("hot")
+	require.Equal(t, 3, fetches["hot"])
+
+	// pinned keys alone exceed the capacity, unpinned ones are evicted
+	for _, key := range keys[1:] {
+		loader.PinUntil(key, now.Add(time.Hour))
+		load(key)
+	}
+	require.Equal(t, len(keys)-1, loader.Len())
+	_, ok := loader.cached("hot")
+	require.False(t, ok)
+
+	// past pins are forgotten, even of keys never loaded
+	loader.PinUntil("cold", now.Add(time.Minute))
+	loader.PinUntil("a", now)
+	require.Len(t, loader.pins, len(keys)-1)
+	now = now.Add(2 * time.Hour)
+	load("hot")
+	require.Empty(t, loader.pins)
+}
@@ -0,0 +1,68 @@
+package groth16_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingMetrics struct {
+	lock     sync.Mutex
+	stages   []string
+	counters map[string]int
+}
+
+func (m *recordingMetrics) ObserveDuration(stage string, _ time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.stages = append(m.stages, stage)
+}
+
+func (m *recordingMetrics) IncCounter(name string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.counters == nil {
+		m.counters = make(map[string]int)
+	}
+	m.counters[name]++
+}
+
+func TestVerifyMetricsAllCurves(t *testing.T) {
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761, ecc.BLS24_315, ecc.BLS24_317, ecc.BW6_633} {
+		t.Run(curveID.String(), func(t *testing.T) {
+			ccs, err := frontend.Compile(curveID.ScalarField(), r1cs.NewBuilder, &snarkjsCircuit{})
+			require.NoError(t, err)
+			pk, vk, err := groth16.Setup(ccs)
+			require.NoError(t, err)
+			fullWitness, err := frontend.NewWitness(&snarkjsCircuit{X: 3, Y: 9, Z: 12}, curveID.ScalarField())
+			require.NoError(t, err)
+			proof, err := groth16.Prove(ccs, pk, fullWitness)
+			require.NoError(t, err)
+			publicWitness, err := fullWitness.Public()
+			require.NoError(t, err)
+
+			m := new(recordingMetrics)
+			require.NoError(t, groth16.Verify(proof, vk, publicWitness, backend.WithVerifierMetrics(m)))
+			require.Equal(t, []string{
+				backend.MetricsStageSubgroup,
+				backend.MetricsStageCommitments,
+				backend.MetricsStageMSM,
+				backend.MetricsStagePairing,
+			}, m.stages)
+			require.Equal(t, map[string]int{backend.MetricsVerifySuccess: 1}, m.counters)
+
+			wrong, err := frontend.NewWitness(&snarkjsCircuit{Y: 9, Z: 13}, curveID.ScalarField(), frontend.PublicOnly())
+			require.NoError(t, err)
+			m = new(recordingMetrics)
+			require.ErrorIs(t, groth16.Verify(proof, vk, wrong, backend.WithVerifierMetrics(m)), groth16.ErrPairingCheckFailed)
+			require.Equal(t, map[string]int{backend.MetricsVerifyFailure: 1}, m.counters)
+		})
+	}
+}
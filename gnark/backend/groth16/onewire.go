@@ -0,0 +1,80 @@
+package groth16
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// OneWirePolicy tells whether serialized public inputs hold the constant 1
+// wire, the coefficient of the first IC point (gamma_abc_g1[0]).
+//
+// Verify always maps the i-th value of the public witness to IC point i+1: the
+// witness never holds the constant 1 wire, and the policy is applied when
+// parsing inputs (see InputParser.WithOneWirePolicy). A witness holding it
+// has one value too many and fails to verify. Sources and their policy:
+//
+//	source                                          policy
+//	snarkjs public.json, generatecall, circom       OneWireImplicit
+//	arkworks Groth16::verify / prepare_inputs       OneWireImplicit
+//	ark-circom CircomBuilder public inputs          OneWireImplicit
+//	bellman verify_proof, libsnark primary input    OneWireImplicit
+//	gnark public witness, Solidity verifier input   OneWireImplicit
+//	arkworks ConstraintSystem instance_assignment   OneWirePresent
+//	circom .wtns witness, first 1+n wires           OneWirePresent
+//
+// libsnark and bellman keep the IC point of the constant 1 wire apart from
+// the others (the first element of the accumulation vector or ic[0]) but
+// their primary inputs are implicit as in arkworks.
+type OneWirePolicy uint8
+
+const (
+	// OneWireImplicit inputs don't hold the constant 1 wire: the i-th input
+	// is the coefficient of IC point i+1.
+	OneWireImplicit OneWirePolicy = iota
+	// OneWirePresent inputs start with the constant 1 wire, which is checked
+	// to be 1 and dropped: the i-th input is the coefficient of IC point i.
+	OneWirePresent
+)
+
+func (p OneWirePolicy) String() string {
+	switch p {
+	case OneWireImplicit:
+		return "implicit"
+	case OneWirePresent:
+		return "present"
+	default:
+		return fmt.Sprintf("OneWirePolicy(%d)", p)
+	}
+}
+
+// apply returns the public inputs of values, serialized with the policy.
+func (p OneWirePolicy) apply(values []*big.Int) ([]*big.Int, error) {
+	switch p {
+	case OneWireImplicit:
+		return values, nil
+	case OneWirePresent:
+		if len(values) == 0 || values[0].Cmp(big.NewInt(1)) != 0 {
+			return nil, errInvalidOneWire
+		}
+		return values[1:], nil
+	default:
+		return nil, fmt.Errorf("unknown one wire policy %s", p)
+	}
+}
+
+// checkOneWire returns an error explaining the mismatch if publicWitness has
+// one value more than vk expects and starts with 1, as when it holds the
+// constant 1 wire.
+func checkOneWire(vk VerifyingKey, publicWitness witness.Witness) error {
+	if reflect.ValueOf(publicWitness.Vector()).Len() != vk.NbPublicWitness()+1 {
+		return nil
+	}
+	values, err := witnessValues(publicWitness)
+	if err != nil || values[0].Cmp(big.NewInt(1)) != 0 {
+		return nil
+	}
+	return fmt.Errorf("%w, got %d, expected %d: the first input is 1, the public witness likely holds the constant 1 wire, parse the inputs with OneWirePresent", ErrInvalidWitnessSize, len(values), vk.NbPublicWitness())
+}
@@ -0,0 +1,72 @@
+package groth16
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOneWirePolicy(t *testing.T) {
+	vkBytes, err := base64.StdEncoding.DecodeString(arkworksVK)
+	require.NoError(t, err)
+	vk, err := ReadArkworksVerifyingKey(ecc.BLS12_381, vkBytes)
+	require.NoError(t, err)
+	proofBytes, err := base64.StdEncoding.DecodeString(arkworksProof)
+	require.NoError(t, err)
+	proof, err := groth16_bls12381.ParseProof(proofBytes)
+	require.NoError(t, err)
+
+	// public inputs as passed to Groth16::verify
+	implicit, err := base64.StdEncoding.DecodeString(arkworksInputs)
+	require.NoError(t, err)
+	// the same as an arkworks instance_assignment, the constant 1 wire first
+	nbInputs := binary.LittleEndian.Uint64(implicit)
+	present := binary.LittleEndian.AppendUint64(nil, nbInputs+1)
+	present = append(present, 1)
+	present = append(present, make([]byte, fr.Bytes-1)...)
+	present = append(present, implicit[8:]...)
+
+	for _, c := range []struct {
+		policy OneWirePolicy
+		data   []byte
+	}{{OneWireImplicit, implicit}, {OneWirePresent, present}} {
+		w, err := NewInputParser(ecc.BLS12_381).WithOneWirePolicy(c.policy).ParseBytes(c.data)
+		require.NoError(t, err, c.policy)
+		require.NoError(t, Verify(proof, vk, w), c.policy)
+	}
+
+	// mismatched policies
+	_, err = NewInputParser(ecc.BLS12_381).WithOneWirePolicy(OneWirePresent).ParseBytes(implicit)
+	require.ErrorIs(t, err, errInvalidOneWire)
+	w, err := NewInputParser(ecc.BLS12_381).WithOneWirePolicy(OneWireImplicit).ParseBytes(present)
+	require.NoError(t, err)
+	err = Verify(proof, vk, w)
+	require.ErrorIs(t, err, ErrInvalidWitnessSize)
+	require.ErrorContains(t, err, "OneWirePresent")
+
+	// decimal inputs (snarkjs public.json) are implicit
+	var x big.Int
+	x.SetBytes(reversed(implicit[8:]))
+	w, err = PublicWitnessFromDecimalLines(ecc.BLS12_381, strings.NewReader(x.String()))
+	require.NoError(t, err)
+	require.NoError(t, Verify(proof, vk, w))
+	w, err = PublicWitnessFromDecimalLines(ecc.BLS12_381, strings.NewReader("1\n"+x.String()))
+	require.NoError(t, err)
+	require.ErrorContains(t, Verify(proof, vk, w), "OneWirePresent")
+
+	_, err = NewInputParser(ecc.BLS12_381).WithOneWirePolicy(42).ParseBytes(implicit)
+	require.ErrorContains(t, err, "unknown one wire policy")
+}
+
+func reversed(b []byte) []byte {
+	r := append([]byte{}, b...)
+	reverse(r)
+	return r
+}
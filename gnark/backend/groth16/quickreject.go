@@ -0,0 +1,22 @@
+package groth16
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+)
+
+// QuickReject checks the points of a serialized arkworks proof one at a time
+// and returns on the first one which is not on the curve or not in the correct
+// subgroup, without parsing the rest. It is meant as a fast filter in front of
+// Verify. Only BLS12-381 is supported.
+func QuickReject(curveID ecc.ID, proofBytes []byte) error {
+	switch curveID {
+	case ecc.BLS12_381:
+		return groth16_bls12381.QuickReject(bytes.NewReader(proofBytes))
+	default:
+		return fmt.Errorf("quick reject is not supported on %s", curveID)
+	}
+}
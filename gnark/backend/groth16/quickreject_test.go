@@ -0,0 +1,24 @@
+package groth16
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickReject(t *testing.T) {
+	proofBytes, err := base64.StdEncoding.DecodeString(arkworksProof)
+	require.NoError(t, err)
+
+	require.NoError(t, QuickReject(ecc.BLS12_381, proofBytes))
+
+	// flip a bit of the x coordinate of Krs
+	corrupted := append([]byte{}, proofBytes...)
+	corrupted[len(corrupted)-60] ^= 1
+	require.ErrorContains(t, QuickReject(ecc.BLS12_381, corrupted), "Krs")
+
+	require.Error(t, QuickReject(ecc.BLS12_381, proofBytes[:100]))
+	require.Error(t, QuickReject(ecc.BN254, proofBytes))
+}
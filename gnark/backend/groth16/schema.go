@@ -0,0 +1,109 @@
+package groth16
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// PublicInputNames returns the names of the public inputs of ccs, as set by the
+// gnark frontend (nested fields joined with "_"). They are in the order of the
+// IC points of a verifying key of the same circuit (vk.G1.K, arkworks
+// gamma_abc_g1), without the constant 1 wire of index 0.
+func PublicInputNames(ccs constraint.ConstraintSystem) ([]string, error) {
+	if _, ok := ccs.(constraint.R1CS); !ok {
+		return nil, errors.New("groth16 requires a R1CS constraint system")
+	}
+	resolver, ok := ccs.(interface{ VariableToString(int) string })
+	if !ok {
+		return nil, errors.New("constraint system doesn't expose its variable names")
+	}
+	nbPublic := ccs.GetNbPublicVariables()
+	if nbPublic == 0 {
+		return nil, errors.New("constraint system has no constant 1 wire")
+	}
+	names := make([]string, nbPublic-1)
+	for i := range names {
+		names[i] = resolver.VariableToString(i + 1)
+	}
+	return names, nil
+}
+
+// PublicWitnessFromSchema builds the public witness of a proof over the circuit
+// compiled into ccs from inputs given by name, ordering them as
+// PublicInputNames does. Values can be of any type accepted by a witness
+// assignment (integers, *big.Int, decimal strings, field elements...).
+//
+// It is useful to verify proofs produced by another prover, e.g. arkworks,
+// for a circuit defined with gnark.
+func PublicWitnessFromSchema(ccs constraint.ConstraintSystem, inputs map[string]any) (witness.Witness, error) {
+	names, err := PublicInputNames(ccs)
+	if err != nil {
+		return nil, err
+	}
+	curveID := utils.FieldToCurve(ccs.Field())
+	if curveID == ecc.UNKNOWN {
+		return nil, errors.New("constraint system is not defined over a supported curve")
+	}
+
+	return publicWitnessFromNames(curveID, names, inputs)
+}
+
+// PublicWitnessFromNamed builds the public witness of a proof for vk from
+// inputs given by name, ordering them by the labels carried by vk (see
+// groth16_bls12381.VerifyingKey.InputLabels). It fails if vk has no labels.
+//
+// Unlike PublicWitnessFromSchema, the names come from the verifying key
+// itself, so they can't drift from a separately distributed schema.
+func PublicWitnessFromNamed(vk VerifyingKey, inputs map[string]any) (witness.Witness, error) {
+	labeled, ok := vk.(interface{ InputLabels() []string })
+	if !ok || labeled.InputLabels() == nil {
+		return nil, errors.New("verifying key has no input labels")
+	}
+	return publicWitnessFromNames(vk.CurveID(), labeled.InputLabels(), inputs)
+}
+
+// publicWitnessFromNames returns the public witness holding inputs in the
+// order of names, which must be exactly the keys of inputs.
+func publicWitnessFromNames(curveID ecc.ID, names []string, inputs map[string]any) (witness.Witness, error) {
+	values := make([]any, len(names))
+	for i, name := range names {
+		v, ok := inputs[name]
+		if !ok {
+			return nil, fmt.Errorf("missing public input %q", name)
+		}
+		values[i] = v
+	}
+	if len(inputs) != len(names) {
+		known := make(map[string]bool, len(names))
+		for _, name := range names {
+			known[name] = true
+		}
+		var unknown []string
+		for name := range inputs {
+			if !known[name] {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown public inputs %q", unknown)
+	}
+
+	return newPublicWitness(curveID, values)
+}
+
+// VerifyWithSchema verifies proof with the public inputs given by name, ordered
+// according to the public schema of ccs. See [PublicWitnessFromSchema].
+func VerifyWithSchema(proof Proof, vk VerifyingKey, ccs constraint.ConstraintSystem, inputs map[string]any, opts ...backend.VerifierOption) error {
+	publicWitness, err := PublicWitnessFromSchema(ccs, inputs)
+	if err != nil {
+		return err
+	}
+	return Verify(proof, vk, publicWitness, opts...)
+}
@@ -0,0 +1,130 @@
+package groth16_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+type schemaPoint struct {
+	X, Y frontend.Variable
+}
+
+// schemaCircuit checks that Sum is the sum of the coordinates of P.
+type schemaCircuit struct {
+	Secret frontend.Variable
+	Sum    frontend.Variable `gnark:",public"`
+	P      schemaPoint       `gnark:",public"`
+}
+
+func (c *schemaCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Add(c.P.X, c.P.Y), c.Sum)
+	api.AssertIsEqual(api.Mul(c.Secret, c.Secret), c.P.X)
+	return nil
+}
+
+func TestVerifyWithSchema(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_381} {
+		ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &schemaCircuit{})
+		assert.NoError(err)
+
+		names, err := groth16.PublicInputNames(ccs)
+		assert.NoError(err)
+		assert.Equal([]string{"Sum", "P_X", "P_Y"}, names)
+
+		pk, vk, err := groth16.Setup(ccs)
+		assert.NoError(err)
+		w, err := frontend.NewWitness(&schemaCircuit{Secret: 3, Sum: 14, P: schemaPoint{X: 9, Y: 5}}, curve.ScalarField())
+		assert.NoError(err)
+		proof, err := groth16.Prove(ccs, pk, w)
+		assert.NoError(err)
+
+		inputs := map[string]any{"P_Y": 5, "Sum": "14", "P_X": 9}
+		assert.NoError(groth16.VerifyWithSchema(proof, vk, ccs, inputs))
+
+		publicWitness, err := groth16.PublicWitnessFromSchema(ccs, inputs)
+		assert.NoError(err)
+		expected, err := w.Public()
+		assert.NoError(err)
+		assert.Equal(expected.Vector(), publicWitness.Vector())
+
+		// swapping two inputs must not verify
+		assert.Error(groth16.VerifyWithSchema(proof, vk, ccs, map[string]any{"P_Y": 9, "Sum": 14, "P_X": 5}))
+
+		_, err = groth16.PublicWitnessFromSchema(ccs, map[string]any{"Sum": 14, "P_X": 9})
+		assert.ErrorContains(err, `missing public input "P_Y"`)
+		_, err = groth16.PublicWitnessFromSchema(ccs, map[string]any{"Sum": 14, "P_X": 9, "P_Y": 5, "Q": 1})
+		assert.ErrorContains(err, `unknown public inputs ["Q"]`)
+	}
+}
+
+func TestPublicWitnessFromNamed(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	ccs, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &schemaCircuit{})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	w, err := frontend.NewWitness(&schemaCircuit{Secret: 3, Sum: 14, P: schemaPoint{X: 9, Y: 5}}, ecc.BLS12_381.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, w)
+	assert.NoError(err)
+
+	inputs := map[string]any{"P_Y": 5, "Sum": 14, "P_X": 9}
+	_, err = groth16.PublicWitnessFromNamed(vk, inputs)
+	assert.ErrorContains(err, "no input labels")
+
+	names, err := groth16.PublicInputNames(ccs)
+	assert.NoError(err)
+	assert.NoError(vk.(*groth16_bls12381.VerifyingKey).SetInputLabels(names))
+
+	publicWitness, err := groth16.PublicWitnessFromNamed(vk, inputs)
+	assert.NoError(err)
+	assert.NoError(groth16.Verify(proof, vk, publicWitness))
+
+	_, err = groth16.PublicWitnessFromNamed(vk, map[string]any{"Sum": 14, "P_X": 9})
+	assert.ErrorContains(err, `missing public input "P_Y"`)
+}
+
+// ExampleVerifyWithSchema shows how to verify a proof of a circuit defined with
+// gnark, passing the public inputs by name.
+func ExampleVerifyWithSchema() {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &schemaCircuit{})
+	if err != nil {
+		panic(err)
+	}
+	// the proof and verifying key could as well come from another prover of the
+	// same circuit
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		panic(err)
+	}
+	w, err := frontend.NewWitness(&schemaCircuit{Secret: 3, Sum: 14, P: schemaPoint{X: 9, Y: 5}}, ecc.BN254.ScalarField())
+	if err != nil {
+		panic(err)
+	}
+	proof, err := groth16.Prove(ccs, pk, w)
+	if err != nil {
+		panic(err)
+	}
+
+	names, err := groth16.PublicInputNames(ccs)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("public inputs:", names)
+
+	err = groth16.VerifyWithSchema(proof, vk, ccs, map[string]any{"Sum": 14, "P_X": 9, "P_Y": 5})
+	fmt.Println("verified:", err == nil)
+	// Output:
+	// public inputs: [Sum P_X P_Y]
+	// verified: true
+}
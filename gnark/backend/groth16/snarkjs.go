@@ -0,0 +1,40 @@
+package groth16
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+// ReadSnarkjsCalldata parses the flat proof and public inputs array emitted by
+// snarkjs generateSolidityCalldata. snarkjs calldata targets the EVM, so only
+// BN254 is supported.
+//
+// The returned witness holds the public inputs only and can be passed directly
+// to Verify.
+func ReadSnarkjsCalldata(curveID ecc.ID, text string) (Proof, witness.Witness, error) {
+	if curveID != ecc.BN254 {
+		return nil, nil, fmt.Errorf("snarkjs calldata is not supported for %s", curveID)
+	}
+	proof, inputs, err := groth16_bn254.ReadSnarkjsCalldata(text)
+	if err != nil {
+		return nil, nil, err
+	}
+	w, err := newPublicWitness(curveID, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, w, nil
+}
+
+// ReadProofFromSolidityCalldata reads a BN254 proof from the 8 uint256 words a
+// Solidity verifier exported by ExportSolidity takes, the inverse of
+// MarshalSolidity for proofs without commitments. The Fp2 coordinates of B
+// are expected in the EVM (A1, A0) order.
+func ReadProofFromSolidityCalldata(words [8]*big.Int) (Proof, error) {
+	return groth16_bn254.ReadProofFromSolidityCalldata(words)
+}
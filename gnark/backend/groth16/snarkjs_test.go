@@ -0,0 +1,208 @@
+package groth16_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+type snarkjsCircuit struct {
+	X    frontend.Variable
+	Y, Z frontend.Variable `gnark:",public"`
+}
+
+func (c *snarkjsCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	api.AssertIsEqual(api.Add(c.X, c.Y), c.Z)
+	return nil
+}
+
+// snarkjsCalldata renders proof and inputs the way snarkjs
+// groth16ExportSolidityCallData does.
+func snarkjsCalldata(proof *groth16_bn254.Proof, inputs fr.Vector) string {
+	p256 := func(e interface{ BigInt(*big.Int) *big.Int }) string {
+		return fmt.Sprintf("\"0x%064x\"", e.BigInt(new(big.Int)))
+	}
+	coords := func(x, y fp.Element) string { return "[" + p256(&x) + ", " + p256(&y) + "]" }
+	in := make([]string, len(inputs))
+	for i := range inputs {
+		in[i] = p256(&inputs[i])
+	}
+	return coords(proof.Ar.X, proof.Ar.Y) + "," +
+		"[" + coords(proof.Bs.X.A1, proof.Bs.X.A0) + "," + coords(proof.Bs.Y.A1, proof.Bs.Y.A0) + "]," +
+		coords(proof.Krs.X, proof.Krs.Y) + "," +
+		"[" + strings.Join(in, ",") + "]"
+}
+
+func TestReadSnarkjsCalldata(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &snarkjsCircuit{})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	fullWitness, err := frontend.NewWitness(&snarkjsCircuit{X: 3, Y: 9, Z: 12}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, fullWitness)
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+
+	calldata := snarkjsCalldata(proof.(*groth16_bn254.Proof), publicWitness.Vector().(fr.Vector))
+
+	parsedProof, parsedWitness, err := groth16.ReadSnarkjsCalldata(ecc.BN254, calldata)
+	assert.NoError(err)
+	assert.Equal(publicWitness.Vector(), parsedWitness.Vector())
+	assert.NoError(groth16.Verify(parsedProof, vk, parsedWitness))
+
+	// swapping the Fp2 components of B back to (A0, A1) must not verify
+	swapped := *proof.(*groth16_bn254.Proof)
+	swapped.Bs.X.A0, swapped.Bs.X.A1 = swapped.Bs.X.A1, swapped.Bs.X.A0
+	swapped.Bs.Y.A0, swapped.Bs.Y.A1 = swapped.Bs.Y.A1, swapped.Bs.Y.A0
+	_, _, err = groth16.ReadSnarkjsCalldata(ecc.BN254, snarkjsCalldata(&swapped, publicWitness.Vector().(fr.Vector)))
+	assert.Error(err)
+
+	_, _, err = groth16.ReadSnarkjsCalldata(ecc.BLS12_381, calldata)
+	assert.Error(err)
+	_, _, err = groth16.ReadSnarkjsCalldata(ecc.BN254, calldata[:len(calldata)/2])
+	assert.Error(err)
+}
+
+func TestReadProofFromSolidityCalldata(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &snarkjsCircuit{})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	fullWitness, err := frontend.NewWitness(&snarkjsCircuit{X: 3, Y: 9, Z: 12}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, fullWitness)
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+
+	calldata := proof.(*groth16_bn254.Proof).MarshalSolidity()
+	assert.Equal(8*fp.Bytes, len(calldata))
+	var words [8]*big.Int
+	for i := range words {
+		words[i] = new(big.Int).SetBytes(calldata[i*fp.Bytes : (i+1)*fp.Bytes])
+	}
+
+	read, err := groth16.ReadProofFromSolidityCalldata(words)
+	assert.NoError(err)
+	original := proof.(*groth16_bn254.Proof)
+	assert.True(read.(*groth16_bn254.Proof).Ar.Equal(&original.Ar))
+	assert.True(read.(*groth16_bn254.Proof).Bs.Equal(&original.Bs))
+	assert.True(read.(*groth16_bn254.Proof).Krs.Equal(&original.Krs))
+	assert.NoError(groth16.Verify(read, vk, publicWitness))
+
+	// B in (A0, A1) order is not on the curve
+	words[2], words[3] = words[3], words[2]
+	words[4], words[5] = words[5], words[4]
+	_, err = groth16.ReadProofFromSolidityCalldata(words)
+	assert.Error(err)
+
+	words[2] = nil
+	_, err = groth16.ReadProofFromSolidityCalldata(words)
+	assert.Error(err)
+	words[2] = fp.Modulus()
+	_, err = groth16.ReadProofFromSolidityCalldata(words)
+	assert.Error(err)
+}
+
+// snarkjsVerifyingKey reads a snarkjs verification_key.json, whose points are
+// decimal projective coordinates with Fp2 elements in (A0, A1) order.
+func snarkjsVerifyingKey(t *testing.T, data []byte) *groth16_bn254.VerifyingKey {
+	var key struct {
+		Protocol string       `json:"protocol"`
+		Curve    string       `json:"curve"`
+		NPublic  int          `json:"nPublic"`
+		Alpha    [3]string    `json:"vk_alpha_1"`
+		Beta     [3][2]string `json:"vk_beta_2"`
+		Gamma    [3][2]string `json:"vk_gamma_2"`
+		Delta    [3][2]string `json:"vk_delta_2"`
+		IC       [][3]string  `json:"IC"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		t.Fatal(err)
+	}
+	if key.Protocol != "groth16" || key.Curve != "bn128" || len(key.IC) != key.NPublic+1 {
+		t.Fatalf("unexpected snarkjs verifying key: %s over %s, %d IC points for %d inputs", key.Protocol, key.Curve, len(key.IC), key.NPublic)
+	}
+	g1 := func(p [3]string) (a bn254.G1Affine) {
+		if p[2] != "1" {
+			t.Fatalf("point not in affine form: %v", p)
+		}
+		a.X.SetString(p[0])
+		a.Y.SetString(p[1])
+		return
+	}
+	g2 := func(p [3][2]string) (a bn254.G2Affine) {
+		if p[2] != [2]string{"1", "0"} {
+			t.Fatalf("point not in affine form: %v", p)
+		}
+		a.X.A0.SetString(p[0][0])
+		a.X.A1.SetString(p[0][1])
+		a.Y.A0.SetString(p[1][0])
+		a.Y.A1.SetString(p[1][1])
+		return
+	}
+
+	var vk groth16_bn254.VerifyingKey
+	vk.G1.Alpha = g1(key.Alpha)
+	vk.G2.Beta, vk.G2.Gamma, vk.G2.Delta = g2(key.Beta), g2(key.Gamma), g2(key.Delta)
+	for _, p := range key.IC {
+		vk.G1.K = append(vk.G1.K, g1(p))
+	}
+	vk.PublicAndCommitmentCommitted = [][]int{}
+	if err := vk.Precompute(); err != nil {
+		t.Fatal(err)
+	}
+	return &vk
+}
+
+// TestReadSnarkjsCalldataFixture checks the reader against the output of
+// snarkjs itself, captured by testdata/snarkjs/generate.sh. It is skipped
+// outside CI when the fixture has not been generated.
+func TestReadSnarkjsCalldataFixture(t *testing.T) {
+	calldata, err := os.ReadFile(filepath.Join("testdata", "snarkjs", "calldata.txt"))
+	if errors.Is(err, fs.ErrNotExist) && os.Getenv("CI") == "" {
+		// CI generates the fixture before running the tests
+		t.Skip("no snarkjs fixture, run testdata/snarkjs/generate.sh")
+	}
+	assert := test.NewAssert(t)
+	assert.NoError(err)
+	vkJSON, err := os.ReadFile(filepath.Join("testdata", "snarkjs", "verification_key.json"))
+	assert.NoError(err)
+	vk := snarkjsVerifyingKey(t, vkJSON)
+
+	proof, publicWitness, err := groth16.ReadSnarkjsCalldata(ecc.BN254, string(calldata))
+	assert.NoError(err)
+	var y, z fr.Element
+	y.SetUint64(9)
+	z.SetUint64(12)
+	assert.Equal(fr.Vector{y, z}, publicWitness.Vector())
+	assert.NoError(groth16.Verify(proof, vk, publicWitness))
+
+	// the same proof for other inputs
+	wrong, err := frontend.NewWitness(&snarkjsCircuit{Y: 9, Z: 13}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	assert.NoError(err)
+	assert.Error(groth16.Verify(proof, vk, wrong))
+}
@@ -0,0 +1,117 @@
+package groth16
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633"
+	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+)
+
+// ErrStructureMismatch is returned when the size of a serialized artifact is
+// impossible for its declared curve, typically because it was produced for
+// another curve.
+var ErrStructureMismatch = errors.New("structure doesn't match the curve")
+
+// compressedPointSizes returns the size of compressed G1 and G2 points of
+// curveID. Uncompressed points are twice as large.
+func compressedPointSizes(curveID ecc.ID) (g1, g2 int, err error) {
+	switch curveID {
+	case ecc.BN254:
+		return bn254.SizeOfG1AffineCompressed, bn254.SizeOfG2AffineCompressed, nil
+	case ecc.BLS12_377:
+		return bls12377.SizeOfG1AffineCompressed, bls12377.SizeOfG2AffineCompressed, nil
+	case ecc.BLS12_381:
+		return bls12381.SizeOfG1AffineCompressed, bls12381.SizeOfG2AffineCompressed, nil
+	case ecc.BLS24_315:
+		return bls24315.SizeOfG1AffineCompressed, bls24315.SizeOfG2AffineCompressed, nil
+	case ecc.BLS24_317:
+		return bls24317.SizeOfG1AffineCompressed, bls24317.SizeOfG2AffineCompressed, nil
+	case ecc.BW6_633:
+		return bw6633.SizeOfG1AffineCompressed, bw6633.SizeOfG2AffineCompressed, nil
+	case ecc.BW6_761:
+		return bw6761.SizeOfG1AffineCompressed, bw6761.SizeOfG2AffineCompressed, nil
+	default:
+		return 0, 0, fmt.Errorf("unsupported curve %s", curveID)
+	}
+}
+
+// checkArkworksVerifyingKeySize checks that data has the size of an arkworks
+// verifying key on curveID, compressed or not: alpha_g1, beta_g2, gamma_g2,
+// delta_g2 and the uint64 little-endian length of gamma_abc_g1 followed by its
+// points, optionally trailed by an input label section (see
+// groth16_bls12381.VerifyingKey.WriteInputLabelsTo), whose content is checked
+// when decoding. Otherwise it returns an error wrapping ErrStructureMismatch
+// with the expected and actual sizes.
+func checkArkworksVerifyingKeySize(curveID ecc.ID, data []byte) error {
+	g1, g2, err := compressedPointSizes(curveID)
+	if err != nil {
+		return err
+	}
+	var expected []string
+	for _, c := range []struct {
+		scale int
+		name  string
+	}{{2, "uncompressed"}, {1, "compressed"}} {
+		g1, g2 := c.scale*g1, c.scale*g2
+		head := g1 + 3*g2 + 8
+		if len(data) < head {
+			expected = append(expected, fmt.Sprintf("at least %d bytes %s", head, c.name))
+			continue
+		}
+		nbK := binary.LittleEndian.Uint64(data[head-8 : head])
+		if nbK <= uint64(len(data)-head)/uint64(g1) {
+			size := head + int(nbK)*g1
+			if size == len(data) || bytes.HasPrefix(data[size:], []byte(groth16_bls12381.InputLabelsMagic)) {
+				return nil
+			}
+		}
+		if nbK <= uint64(len(data)) {
+			expected = append(expected, fmt.Sprintf("%d bytes %s for %d IC points", head+int(nbK)*g1, c.name, nbK))
+		} else {
+			expected = append(expected, fmt.Sprintf("%d IC points %s, an impossible count", nbK, c.name))
+		}
+	}
+	return fmt.Errorf("%w: %s verifying key: expected %s, got %d bytes", ErrStructureMismatch, curveID, strings.Join(expected, " or "), len(data))
+}
+
+// ReadArkworksVerifyingKey reads a verifying key serialized by arkworks
+// CanonicalSerialize, compressed or not, on the declared curve. Before decoding
+// it checks that the size of data is possible for the curve, and returns an
+// error wrapping ErrStructureMismatch naming the expected and actual sizes
+// otherwise, e.g. for a BLS12-381 key labeled as BN254.
+//
+// Only BLS12-381 keys can be decoded for now, with groth16_bls12381.ParseVerifyingKey,
+// which also reads the input labels trailing the key if any.
+//
+// Keys and proofs of arkworks Groth16<E, QAP> are read the same whatever the
+// R1CS to QAP reduction, LibsnarkReduction (the default) or CircomReduction:
+// the reduction only changes how the prover computes the quotient polynomial
+// and which QAP the setup encodes, while gamma_abc_g1 keeps the same layout,
+// the constant 1 wire at index 0 followed by the public inputs in order, and
+// the verification equation is the same. There is thus no option to select
+// it; a valid proof failing to verify has other causes, such as public inputs
+// in another order or form (see InputParser).
+func ReadArkworksVerifyingKey(curveID ecc.ID, data []byte) (VerifyingKey, error) {
+	if err := checkArkworksVerifyingKeySize(curveID, data); err != nil {
+		return nil, err
+	}
+	if curveID != ecc.BLS12_381 {
+		return nil, fmt.Errorf("reading arkworks verifying keys on %s is not supported", curveID)
+	}
+	vk, err := groth16_bls12381.ParseVerifyingKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return vk, nil
+}
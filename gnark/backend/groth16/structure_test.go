@@ -0,0 +1,291 @@
+package groth16
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadArkworksVerifyingKeyStructure(t *testing.T) {
+	vkBytes, err := base64.StdEncoding.DecodeString(arkworksVK)
+	require.NoError(t, err)
+
+	require.NoError(t, checkArkworksVerifyingKeySize(ecc.BLS12_381, vkBytes))
+
+	// a BLS12-381 key labeled as BN254
+	_, err = ReadArkworksVerifyingKey(ecc.BN254, vkBytes)
+	require.ErrorIs(t, err, ErrStructureMismatch)
+	require.ErrorContains(t, err, "bn254 verifying key")
+	require.ErrorContains(t, err, "got 872 bytes")
+
+	// truncated key
+	err = checkArkworksVerifyingKeySize(ecc.BLS12_381, vkBytes[:len(vkBytes)-1])
+	require.ErrorIs(t, err, ErrStructureMismatch)
+	require.ErrorContains(t, err, "expected 872 bytes uncompressed for 2 IC points")
+
+	// a BN254-sized key labeled as BLS12-381
+	bn254VK := make([]byte, 64+3*128+8+2*64)
+	bn254VK[64+3*128] = 2
+	require.NoError(t, checkArkworksVerifyingKeySize(ecc.BN254, bn254VK))
+	_, err = ReadArkworksVerifyingKey(ecc.BLS12_381, bn254VK)
+	require.ErrorIs(t, err, ErrStructureMismatch)
+	require.ErrorContains(t, err, "at least 680 bytes uncompressed")
+}
+
+func TestReadArkworksVerifyingKeyLabels(t *testing.T) {
+	vkBytes, err := base64.StdEncoding.DecodeString(arkworksVK)
+	require.NoError(t, err)
+
+	// one public input, labeled "x"; the key itself is written back as arkworks
+	// wrote it
+	unlabeled, err := groth16_bls12381.ParseVerifyingKey(vkBytes)
+	require.NoError(t, err)
+	require.NoError(t, unlabeled.SetInputLabels([]string{"x"}))
+	var buf bytes.Buffer
+	_, err = unlabeled.WriteArkworksRawTo(&buf)
+	require.NoError(t, err)
+	labeled := buf.Bytes()
+	require.Equal(t, vkBytes, labeled[:len(vkBytes)])
+
+	require.NoError(t, checkArkworksVerifyingKeySize(ecc.BLS12_381, labeled))
+	vk, err := ReadArkworksVerifyingKey(ecc.BLS12_381, labeled)
+	require.NoError(t, err)
+	require.Equal(t, []string{"x"}, vk.(*groth16_bls12381.VerifyingKey).InputLabels())
+
+	loader, err := NewVerifyingKeyLoader(ecc.BLS12_381, MemoryFetcher(map[string][]byte{"x": labeled}), 1)
+	require.NoError(t, err)
+	vk, err = loader.Load(context.Background(), "x")
+	require.NoError(t, err)
+	require.Equal(t, []string{"x"}, vk.(*groth16_bls12381.VerifyingKey).InputLabels())
+
+	// other trailing bytes are still a mismatch
+	err = checkArkworksVerifyingKeySize(ecc.BLS12_381, append(bytes.Clone(vkBytes), "ABCD"...))
+	require.ErrorIs(t, err, ErrStructureMismatch)
+}
+
+// TestReadArkworksVerifyingKeyReductions proves x·x = y on BLS12-381 from a
+// known setup with the two R1CS to QAP reductions of arkworks Groth16. The
+// LibsnarkReduction prover commits to the coefficients of the quotient
+// (A·B - C)/Z. The CircomReduction prover of ark-circom commits to A·B - C
+// on the odd 2n-th roots of unity, against the Lagrange basis of snarkjs.
+// Both proofs verify against the same key read by ReadArkworksVerifyingKey.
+func TestReadArkworksVerifyingKeyReductions(t *testing.T) {
+	// wires 1, y, x; the constraint x·x = y, then 1·0 = 0 and y·0 = 0 that
+	// arkworks appends for the inputs, padded to a power of 2
+	const n = 4
+	matA := [n][3]uint64{{0, 0, 1}, {1, 0, 0}, {0, 1, 0}}
+	matB := [n][3]uint64{{0, 0, 1}}
+	matC := [n][3]uint64{{0, 1, 0}}
+	var w [3]fr.Element
+	w[0].SetOne()
+	w[1].SetUint64(9)
+	w[2].SetUint64(3)
+
+	omega, err := fr.Generator(n)
+	require.NoError(t, err)
+	omega2n, err := fr.Generator(2 * n)
+	require.NoError(t, err)
+
+	var tau, alpha, beta, gamma, delta fr.Element
+	for _, e := range []*fr.Element{&tau, &alpha, &beta, &gamma, &delta} {
+		_, err := e.SetRandom()
+		require.NoError(t, err)
+	}
+	var deltaInv, gammaInv fr.Element
+	deltaInv.Inverse(&delta)
+	gammaInv.Inverse(&gamma)
+
+	// the wire polynomials at tau, and the rows of the witness
+	var at, bt, ct [3]fr.Element
+	var rowsA, rowsB, rowsC [n]fr.Element
+	for k := 0; k < n; k++ {
+		l := qapLagrange(n, omega, k, tau)
+		for i := range w {
+			for _, m := range []struct {
+				mat  *[n][3]uint64
+				at   *fr.Element
+				rows *fr.Element
+			}{{&matA, &at[i], &rowsA[k]}, {&matB, &bt[i], &rowsB[k]}, {&matC, &ct[i], &rowsC[k]}} {
+				var c, v fr.Element
+				c.SetUint64(m.mat[k][i])
+				m.at.Add(m.at, v.Mul(&c, &l))
+				m.rows.Add(m.rows, v.Mul(&c, &w[i]))
+			}
+		}
+	}
+	polyA, polyB, polyC := qapInterpolate(rowsA[:], omega), qapInterpolate(rowsB[:], omega), qapInterpolate(rowsC[:], omega)
+
+	// β·a_i(τ) + α·b_i(τ) + c_i(τ)
+	wireTerm := func(i int) fr.Element {
+		var res, v fr.Element
+		res.Mul(&beta, &at[i])
+		res.Add(&res, v.Mul(&alpha, &bt[i]))
+		return *res.Add(&res, &ct[i])
+	}
+	g1 := func(e fr.Element) (p curve.G1Affine) {
+		p.ScalarMultiplicationBase(e.BigInt(new(big.Int)))
+		return
+	}
+	g2 := func(e fr.Element) (p curve.G2Affine) {
+		p.ScalarMultiplicationBase(e.BigInt(new(big.Int)))
+		return
+	}
+
+	var vk groth16_bls12381.VerifyingKey
+	vk.G1.Alpha = g1(alpha)
+	vk.G2.Beta, vk.G2.Gamma, vk.G2.Delta = g2(beta), g2(gamma), g2(delta)
+	for i := 0; i < 2; i++ {
+		k := wireTerm(i)
+		vk.G1.K = append(vk.G1.K, g1(*k.Mul(&k, &gammaInv)))
+	}
+	var buf bytes.Buffer
+	_, err = vk.WriteArkworksRawTo(&buf)
+	require.NoError(t, err)
+	read, err := ReadArkworksVerifyingKey(ecc.BLS12_381, buf.Bytes())
+	require.NoError(t, err)
+	readVK := read.(*groth16_bls12381.VerifyingKey)
+
+	// h is the sum of the h_query terms of the proving key
+	prove := func(h fr.Element) *groth16_bls12381.Proof {
+		var r, s fr.Element
+		_, err := r.SetRandom()
+		require.NoError(t, err)
+		_, err = s.SetRandom()
+		require.NoError(t, err)
+		var a, b, c, v fr.Element
+		a.Add(&alpha, v.Mul(&r, &delta))
+		b.Add(&beta, v.Mul(&s, &delta))
+		for i := range w {
+			a.Add(&a, v.Mul(&w[i], &at[i]))
+			b.Add(&b, v.Mul(&w[i], &bt[i]))
+		}
+		c = wireTerm(2)
+		c.Mul(&c, &w[2]).Mul(&c, &deltaInv).Add(&c, &h)
+		c.Add(&c, v.Mul(&s, &a))
+		c.Add(&c, v.Mul(&r, &b))
+		c.Sub(&c, v.Mul(&r, &s).Mul(&v, &delta))
+
+		ar, bs, krs := g1(a), g2(b), g1(c)
+		arBytes, bsBytes, krsBytes := ar.Bytes(), bs.Bytes(), krs.Bytes()
+		proof, err := groth16_bls12381.ParseProof(append(append(arBytes[:], bsBytes[:]...), krsBytes[:]...))
+		require.NoError(t, err)
+		return proof
+	}
+
+	var zt fr.Element
+	zt.Exp(tau, big.NewInt(n))
+	zt.Sub(&zt, new(fr.Element).SetOne())
+
+	// LibsnarkReduction: h_query[i] = τ^i·Z(τ)/δ
+	p := qapSub(qapMul(polyA, polyB), polyC)
+	quotient := make([]fr.Element, n-1)
+	for d := len(p) - 1; d >= n; d-- {
+		quotient[d-n] = p[d]
+		p[d-n].Add(&p[d-n], &p[d])
+		p[d].SetZero()
+	}
+	for _, e := range p {
+		require.True(t, e.IsZero(), "the witness satisfies the constraints")
+	}
+	libsnarkH := qapEval(quotient, tau)
+	libsnarkH.Mul(&libsnarkH, &zt).Mul(&libsnarkH, &deltaInv)
+	require.NoError(t, groth16_bls12381.Verify(prove(libsnarkH), readVK, fr.Vector{w[1]}))
+
+	// CircomReduction: h_query[j] = L_{2j+1}(τ)/δ on the 2n-th roots of unity
+	var circomH, mixedH, tauPow fr.Element
+	tauPow.SetOne()
+	for j := 0; j < n; j++ {
+		var x, e, v fr.Element
+		x.Exp(omega2n, big.NewInt(int64(2*j+1)))
+		e = qapEval(polyA, x)
+		v = qapEval(polyB, x)
+		e.Mul(&e, &v)
+		v = qapEval(polyC, x)
+		e.Sub(&e, &v)
+		l := qapLagrange(2*n, omega2n, 2*j+1, tau)
+		circomH.Add(&circomH, v.Mul(&e, &l))
+		// the same evaluations against the libsnark h_query
+		mixedH.Add(&mixedH, v.Mul(&e, &tauPow))
+		tauPow.Mul(&tauPow, &tau)
+	}
+	circomH.Mul(&circomH, &deltaInv)
+	mixedH.Mul(&mixedH, &zt).Mul(&mixedH, &deltaInv)
+	proof := prove(circomH)
+	require.NoError(t, groth16_bls12381.Verify(proof, readVK, fr.Vector{w[1]}))
+
+	var wrong fr.Element
+	wrong.SetUint64(10)
+	require.Error(t, groth16_bls12381.Verify(proof, readVK, fr.Vector{wrong}))
+	require.Error(t, groth16_bls12381.Verify(prove(mixedH), readVK, fr.Vector{w[1]}))
+}
+
+// qapLagrange returns the k-th Lagrange polynomial of the n-th roots of unity
+// at x, ω^k·(x^n - 1) / (n·(x - ω^k)).
+func qapLagrange(n int, omega fr.Element, k int, x fr.Element) fr.Element {
+	var omegaK, num, den fr.Element
+	omegaK.Exp(omega, big.NewInt(int64(k)))
+	num.Exp(x, big.NewInt(int64(n)))
+	num.Sub(&num, new(fr.Element).SetOne()).Mul(&num, &omegaK)
+	den.SetUint64(uint64(n))
+	den.Mul(&den, new(fr.Element).Sub(&x, &omegaK))
+	return *num.Div(&num, &den)
+}
+
+// qapInterpolate returns the coefficients of the polynomial taking the values
+// evals on the roots of unity.
+func qapInterpolate(evals []fr.Element, omega fr.Element) []fr.Element {
+	res := make([]fr.Element, len(evals))
+	for k := range evals {
+		l := make([]fr.Element, len(evals))
+		// L_k = Σ_i ω^{-ik}·X^i / n
+		var omegaInvK, pow, n fr.Element
+		omegaInvK.Exp(omega, big.NewInt(int64(k)))
+		omegaInvK.Inverse(&omegaInvK)
+		n.SetUint64(uint64(len(evals)))
+		pow.Inverse(&n)
+		for i := range l {
+			l[i] = pow
+			pow.Mul(&pow, &omegaInvK)
+		}
+		for i := range res {
+			var v fr.Element
+			res[i].Add(&res[i], v.Mul(&l[i], &evals[k]))
+		}
+	}
+	return res
+}
+
+func qapEval(coeffs []fr.Element, x fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		res.Mul(&res, &x).Add(&res, &coeffs[i])
+	}
+	return res
+}
+
+func qapMul(a, b []fr.Element) []fr.Element {
+	res := make([]fr.Element, len(a)+len(b)-1)
+	for i := range a {
+		for j := range b {
+			var v fr.Element
+			res[i+j].Add(&res[i+j], v.Mul(&a[i], &b[j]))
+		}
+	}
+	return res
+}
+
+func qapSub(a, b []fr.Element) []fr.Element {
+	res := append([]fr.Element(nil), a...)
+	for i := range b {
+		res[i].Sub(&res[i], &b[i])
+	}
+	return res
+}
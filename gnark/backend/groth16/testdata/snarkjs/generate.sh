@@ -0,0 +1,20 @@
+#!/bin/sh
+# Captures the snarkjs fixture of TestReadSnarkjsCalldataFixture: a proof of
+# square.circom for x = 3, exported by snarkjs generateSolidityCalldata, and
+# its verifying key. Needs circom 2 and snarkjs on the PATH.
+set -e
+cd "$(dirname "$0")"
+tmp=$(mktemp -d)
+trap 'rm -rf "$tmp"' EXIT
+
+circom square.circom --r1cs --wasm -o "$tmp"
+snarkjs powersoftau new bn128 4 "$tmp/pot_0.ptau"
+snarkjs powersoftau contribute "$tmp/pot_0.ptau" "$tmp/pot_1.ptau" -e=fixture
+snarkjs powersoftau prepare phase2 "$tmp/pot_1.ptau" "$tmp/pot.ptau"
+snarkjs groth16 setup "$tmp/square.r1cs" "$tmp/pot.ptau" "$tmp/square_0.zkey"
+snarkjs zkey contribute "$tmp/square_0.zkey" "$tmp/square.zkey" -e=fixture
+snarkjs zkey export verificationkey "$tmp/square.zkey" verification_key.json
+
+echo '{"x": 3, "y": 9, "z": 12}' >"$tmp/input.json"
+snarkjs groth16 fullprove "$tmp/input.json" "$tmp/square_js/square.wasm" "$tmp/square.zkey" "$tmp/proof.json" "$tmp/public.json"
+snarkjs zkey export soliditycalldata "$tmp/public.json" "$tmp/proof.json" >calldata.txt
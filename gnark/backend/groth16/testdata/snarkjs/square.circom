@@ -0,0 +1,13 @@
+pragma circom 2.0.0;
+
+// snarkjsCircuit of snarkjs_test.go: x² = y, x + y = z, with y and z public.
+template Square() {
+    signal input x;
+    signal input y;
+    signal input z;
+
+    y === x * x;
+    z === x + y;
+}
+
+component main {public [y, z]} = Square();
@@ -0,0 +1,51 @@
+package groth16
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// TwistType is the type of the sextic twist E'(Fp²) on which the G2 points of
+// a curve lie. With ξ the non-residue defining the twist and E: y² = x³ + b,
+// a D-type twist is E': y² = x³ + b/ξ and an M-type twist is E': y² = x³ + b·ξ.
+//
+// The twist type changes the line functions of the Miller loop, which
+// gnark-crypto implements per curve, and the curve equation G2 points are
+// checked against. This package doesn't evaluate pairings or map G2
+// coordinates itself: readers only reorder the Fp² components for the
+// external format (arkworks/zcash c1 first, EVM A1 first) before handing the
+// points to gnark-crypto, so the same code is correct for both twist types.
+type TwistType uint8
+
+const (
+	// DTwist is the twist of BN254: ξ = 9 + u, E': y² = x³ + 3/ξ.
+	DTwist TwistType = iota + 1
+	// MTwist is the twist of BLS12-381: ξ = 1 + u, E': y² = x³ + 4·ξ.
+	MTwist
+)
+
+// String returns the string representation of a twist type
+func (t TwistType) String() string {
+	switch t {
+	case DTwist:
+		return "D-type"
+	case MTwist:
+		return "M-type"
+	default:
+		return "unknown"
+	}
+}
+
+// CurveTwist returns the twist type of the curves the arkworks and EVM readers
+// of this package support.
+func CurveTwist(curveID ecc.ID) (TwistType, error) {
+	switch curveID {
+	case ecc.BN254:
+		return DTwist, nil
+	case ecc.BLS12_381:
+		return MTwist, nil
+	default:
+		return 0, fmt.Errorf("twist type of %s is not documented", curveID)
+	}
+}
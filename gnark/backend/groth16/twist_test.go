@@ -0,0 +1,86 @@
+package groth16_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func TestCurveTwist(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// recover b' = y² - x³ from the G2 generators and check it against b/ξ or b·ξ
+	{
+		twist, err := groth16.CurveTwist(ecc.BN254)
+		assert.NoError(err)
+		assert.Equal(groth16.DTwist, twist)
+
+		_, _, _, g2 := bn254.Generators()
+		b, x3 := g2.Y, g2.X
+		b.Square(&b)
+		x3.Square(&x3).Mul(&x3, &g2.X)
+		b.Sub(&b, &x3)
+		xi := g2.X
+		xi.A0.SetUint64(9)
+		xi.A1.SetOne()
+		b.Mul(&b, &xi)
+		assert.True(b.A0.IsUint64() && b.A0.Uint64() == 3 && b.A1.IsZero(), "BN254 twist is not b/ξ")
+	}
+	{
+		twist, err := groth16.CurveTwist(ecc.BLS12_381)
+		assert.NoError(err)
+		assert.Equal(groth16.MTwist, twist)
+
+		_, _, _, g2 := bls12381.Generators()
+		b, x3 := g2.Y, g2.X
+		b.Square(&b)
+		x3.Square(&x3).Mul(&x3, &g2.X)
+		b.Sub(&b, &x3)
+		assert.True(b.A0.IsUint64() && b.A0.Uint64() == 4 && b.A1.IsUint64() && b.A1.Uint64() == 4, "BLS12-381 twist is not b·ξ")
+	}
+
+	_, err := groth16.CurveTwist(ecc.BW6_761)
+	assert.Error(err)
+}
+
+// TestTwistProofs checks that proofs verify on both twist types and that the
+// G2 point of the proof is bound to the twist: swapping its Fp² components,
+// the classic mistake when mapping coordinates, leaves the twist.
+func TestTwistProofs(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_381} {
+		ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &snarkjsCircuit{})
+		assert.NoError(err)
+		pk, vk, err := groth16.Setup(ccs)
+		assert.NoError(err)
+		w, err := frontend.NewWitness(&snarkjsCircuit{X: 3, Y: 9, Z: 12}, curve.ScalarField())
+		assert.NoError(err)
+		proof, err := groth16.Prove(ccs, pk, w)
+		assert.NoError(err)
+		publicWitness, err := w.Public()
+		assert.NoError(err)
+		assert.NoError(groth16.Verify(proof, vk, publicWitness))
+
+		switch p := proof.(type) {
+		case *groth16_bn254.Proof:
+			bs := p.Bs
+			bs.X.A0, bs.X.A1 = bs.X.A1, bs.X.A0
+			bs.Y.A0, bs.Y.A1 = bs.Y.A1, bs.Y.A0
+			assert.False(bs.IsOnCurve())
+		case *groth16_bls12381.Proof:
+			bs := p.Bs
+			bs.X.A0, bs.X.A1 = bs.X.A1, bs.X.A0
+			bs.Y.A0, bs.Y.A1 = bs.Y.A1, bs.Y.A0
+			assert.False(bs.IsOnCurve())
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package groth16
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16/internal"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// Errors returned by Verify, whatever the curve.
+var (
+	ErrPairingCheckFailed         = internal.ErrPairingCheckFailed
+	ErrCorrectSubgroupCheckFailed = internal.ErrCorrectSubgroupCheckFailed
+	ErrInvalidWitnessSize         = internal.ErrInvalidWitnessSize
+	ErrCommitmentBasisMismatch    = internal.ErrCommitmentBasisMismatch
+	ErrCurveMismatch              = errors.New("proof and verifying key are on different curves")
+)
+
+// Verification result codes returned by VerifyCode. They are part of the API:
+// a code keeps its meaning and value across versions, and new failure modes
+// get new codes.
+const (
+	// CodeOK means the proof is valid.
+	CodeOK = 0
+	// CodePairingFailed means the pairing check failed: the proof is invalid
+	// for this verifying key and these public inputs.
+	CodePairingFailed = 1
+	// CodeInputCountMismatch means the number of public inputs doesn't match
+	// the verifying key.
+	CodeInputCountMismatch = 2
+	// CodeSubgroupCheckFailed means a point of the proof is not on the curve
+	// or not in the correct subgroup.
+	CodeSubgroupCheckFailed = 3
+	// CodeCurveMismatch means the proof and verifying key are on different
+	// curves.
+	CodeCurveMismatch = 4
+	// CodeInvalidWitness means the witness is not over the scalar field of the
+	// proof's curve.
+	CodeInvalidWitness = 5
+	// CodeFullWitness means the witness holds secret values.
+	CodeFullWitness = 6
+	// CodeCommitmentBasisMismatch means the commitment proof of knowledge
+	// doesn't hold for the commitment basis configured on the verifying key
+	// (see the BLS12-381 VerifyingKey.WithCommitmentBasis).
+	CodeCommitmentBasisMismatch = 7
+	// CodeOther is any other failure, such as an invalid commitment or
+	// verifier option.
+	CodeOther = 255
+)
+
+// VerifyCode verifies proof as Verify does and returns the result as one of
+// the Code constants, a language-neutral contract for foreign callers.
+func VerifyCode(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ...backend.VerifierOption) int {
+	return ErrorCode(verifyChecked(proof, vk, publicWitness, opts...))
+}
+
+// ErrorCode returns the code of an error returned by Verify.
+func ErrorCode(err error) int {
+	switch {
+	case err == nil:
+		return CodeOK
+	case errors.Is(err, ErrPairingCheckFailed):
+		return CodePairingFailed
+	case errors.Is(err, ErrInvalidWitnessSize):
+		return CodeInputCountMismatch
+	case errors.Is(err, ErrCorrectSubgroupCheckFailed):
+		return CodeSubgroupCheckFailed
+	case errors.Is(err, ErrCurveMismatch):
+		return CodeCurveMismatch
+	case errors.Is(err, witness.ErrInvalidWitness):
+		return CodeInvalidWitness
+	case errors.Is(err, ErrFullWitness):
+		return CodeFullWitness
+	case errors.Is(err, ErrCommitmentBasisMismatch):
+		return CodeCommitmentBasisMismatch
+	default:
+		return CodeOther
+	}
+}
+
+// verifyChecked calls Verify, first checking that proof and vk are on the same
+// curve as Verify panics otherwise.
+func verifyChecked(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ...backend.VerifierOption) error {
+	if proof.CurveID() != vk.CurveID() {
+		return ErrCurveMismatch
+	}
+	return Verify(proof, vk, publicWitness, opts...)
+}
@@ -0,0 +1,101 @@
+package groth16_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func TestVerifyCode(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &snarkjsCircuit{})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	fullWitness, err := frontend.NewWitness(&snarkjsCircuit{X: 3, Y: 9, Z: 12}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, fullWitness)
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+
+	parse := func(curveID ecc.ID, lines string) witness.Witness {
+		w, err := groth16.PublicWitnessFromDecimalLines(curveID, strings.NewReader(lines))
+		assert.NoError(err)
+		return w
+	}
+	offCurve := *proof.(*groth16_bn254.Proof)
+	offCurve.Ar.Y.Double(&offCurve.Ar.Y)
+
+	for _, c := range []struct {
+		name  string
+		proof groth16.Proof
+		w     witness.Witness
+		opts  []backend.VerifierOption
+		code  int
+	}{
+		{"valid", proof, publicWitness, nil, groth16.CodeOK},
+		{"wrong input", proof, parse(ecc.BN254, "9\n13"), nil, groth16.CodePairingFailed},
+		{"missing input", proof, parse(ecc.BN254, "9"), nil, groth16.CodeInputCountMismatch},
+		{"off curve", &offCurve, publicWitness, nil, groth16.CodeSubgroupCheckFailed},
+		{"other curve", &groth16_bls12381.Proof{}, publicWitness, nil, groth16.CodeCurveMismatch},
+		{"other field", proof, parse(ecc.BLS12_381, "9\n12"), nil, groth16.CodeInvalidWitness},
+		{"full witness", proof, fullWitness, nil, groth16.CodeFullWitness},
+		{"invalid option", proof, publicWitness, []backend.VerifierOption{backend.WithVerifierSubgroupCheckMethod(42)}, groth16.CodeOther},
+		{"unsupported subgroup check", proof, publicWitness, []backend.VerifierOption{backend.WithVerifierSubgroupCheckMethod(backend.SubgroupCheckScalarMul)}, groth16.CodeOther},
+	} {
+		assert.Equal(c.code, groth16.VerifyCode(c.proof, vk, c.w, c.opts...), c.name)
+	}
+}
+
+type committedSquareCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *committedSquareCircuit) Define(api frontend.API) error {
+	commitment, err := api.(frontend.Committer).Commit(c.X, c.Y)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(commitment, 0)
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+func TestVerifyCodeCommitmentBasis(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	ccs, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &committedSquareCircuit{})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	fullWitness, err := frontend.NewWitness(&committedSquareCircuit{X: 3, Y: 9}, ecc.BLS12_381.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, fullWitness)
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+
+	// G scaled alone: the proof of knowledge doesn't hold for the basis
+	basis := groth16_bls12381.CommitmentBasis{Curve: ecc.BLS12_381, Key: vk.(*groth16_bls12381.VerifyingKey).CommitmentKey}
+	basis.Key.G.Double(&basis.Key.G)
+	mismatched, err := vk.(*groth16_bls12381.VerifyingKey).WithCommitmentBasis(basis)
+	assert.NoError(err)
+	assert.Equal(groth16.CodeOK, groth16.VerifyCode(proof, vk, publicWitness))
+	assert.Equal(groth16.CodeCommitmentBasisMismatch, groth16.VerifyCode(proof, mismatched, publicWitness))
+
+	tampered := *proof.(*groth16_bls12381.Proof)
+	tampered.CommitmentPok.Double(&tampered.CommitmentPok)
+	assert.Equal(groth16.CodeOther, groth16.VerifyCode(&tampered, vk, publicWitness))
+}
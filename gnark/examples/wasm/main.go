@@ -0,0 +1,49 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"errors"
+	"syscall/js"
+
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+// main registers the verifier functions on the global object and blocks, so
+// that JavaScript can call them:
+//
+//	gnarkVerify(curve, vk, proof, inputs) // curve name and Uint8Arrays
+//	gnarkVerifySnarkjs(vk, calldata)      // Uint8Array and string
+//
+// Both return an object {code, error}, code being a groth16.VerifyCode result
+// (0 for a valid proof) and error a message when the artifacts couldn't be
+// read.
+func main() {
+	js.Global().Set("gnarkVerify", js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if len(args) != 4 {
+			return result(groth16.CodeOther, errors.New("usage: gnarkVerify(curve, vk, proof, inputs)"))
+		}
+		return result(verify(args[0].String(), toBytes(args[1]), toBytes(args[2]), toBytes(args[3])))
+	}))
+	js.Global().Set("gnarkVerifySnarkjs", js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if len(args) != 2 {
+			return result(groth16.CodeOther, errors.New("usage: gnarkVerifySnarkjs(vk, calldata)"))
+		}
+		return result(verifySnarkjs(toBytes(args[0]), args[1].String()))
+	}))
+	select {}
+}
+
+func toBytes(v js.Value) []byte {
+	b := make([]byte, v.Get("length").Int())
+	js.CopyBytesToGo(b, v)
+	return b
+}
+
+func result(code int, err error) any {
+	res := map[string]any{"code": code, "error": nil}
+	if err != nil {
+		res["error"] = err.Error()
+	}
+	return res
+}
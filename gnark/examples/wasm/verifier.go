@@ -0,0 +1,78 @@
+//go:build js && wasm
+
+// Package main is a WebAssembly build of the groth16 verifier, exposing it to
+// JavaScript. Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o verifier.wasm ./examples/wasm
+//
+// and serve it next to index.html and wasm_exec.js, found in
+// $(go env GOROOT)/lib/wasm (misc/wasm before Go 1.24). The tests can run
+// under Node.js with
+//
+//	GOOS=js GOARCH=wasm go test -exec="$(go env GOROOT)/lib/wasm/go_js_wasm_exec" ./examples/wasm
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+// verify reads a verifying key and proof serialized with WriteTo, or as
+// arkworks serializes them on BLS12-381 (see groth16.ReadArkworksVerifyingKey),
+// and public inputs as an arkworks Vec<F> (see groth16.NewInputParser), and
+// returns the groth16.VerifyCode result. A non-nil error means the artifacts couldn't be
+// read, and the code is then groth16.CodeOther.
+func verify(curve string, vkBytes, proofBytes, inputs []byte) (int, error) {
+	curveID, err := ecc.IDFromString(curve)
+	if err != nil {
+		return groth16.CodeOther, err
+	}
+	switch curveID {
+	case ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761, ecc.BLS24_315, ecc.BLS24_317, ecc.BW6_633:
+	default:
+		return groth16.CodeOther, fmt.Errorf("groth16 is not implemented over %s", curveID)
+	}
+	vk, err := readVerifyingKey(curveID, vkBytes)
+	if err != nil {
+		return groth16.CodeOther, fmt.Errorf("read verifying key: %w", err)
+	}
+	proof := groth16.NewProof(curveID)
+	if _, err := proof.ReadFrom(bytes.NewReader(proofBytes)); err != nil {
+		return groth16.CodeOther, fmt.Errorf("read proof: %w", err)
+	}
+	publicWitness, err := groth16.NewInputParser(curveID).ParseBytes(inputs)
+	if err != nil {
+		return groth16.CodeOther, fmt.Errorf("read public inputs: %w", err)
+	}
+	return groth16.VerifyCode(proof, vk, publicWitness), nil
+}
+
+// readVerifyingKey reads BLS12-381 keys as arkworks serializes them, and the
+// keys of the other curves with ReadFrom.
+func readVerifyingKey(curveID ecc.ID, data []byte) (groth16.VerifyingKey, error) {
+	if curveID == ecc.BLS12_381 {
+		return groth16.ReadArkworksVerifyingKey(curveID, data)
+	}
+	vk := groth16.NewVerifyingKey(curveID)
+	if _, err := vk.ReadFrom(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return vk, nil
+}
+
+// verifySnarkjs verifies the output of snarkjs generatecall against a BN254
+// verifying key serialized with WriteTo.
+func verifySnarkjs(vkBytes []byte, calldata string) (int, error) {
+	vk := groth16.NewVerifyingKey(ecc.BN254)
+	if _, err := vk.ReadFrom(bytes.NewReader(vkBytes)); err != nil {
+		return groth16.CodeOther, fmt.Errorf("read verifying key: %w", err)
+	}
+	proof, publicWitness, err := groth16.ReadSnarkjsCalldata(ecc.BN254, calldata)
+	if err != nil {
+		return groth16.CodeOther, fmt.Errorf("read calldata: %w", err)
+	}
+	return groth16.VerifyCode(proof, vk, publicWitness), nil
+}
@@ -0,0 +1,126 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/examples/cubic"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func TestVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit cubic.Circuit
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	w, err := frontend.NewWitness(&cubic.Circuit{X: 3, Y: 35}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, w)
+	assert.NoError(err)
+
+	var vkBytes, proofBytes bytes.Buffer
+	_, err = vk.WriteTo(&vkBytes)
+	assert.NoError(err)
+	_, err = proof.WriteTo(&proofBytes)
+	assert.NoError(err)
+
+	// arkworks Vec<F>: uint64 count, little-endian elements
+	input := func(v int64) []byte {
+		b := append([]byte{1, 0, 0, 0, 0, 0, 0, 0}, big.NewInt(v).FillBytes(make([]byte, fr.Bytes))...)
+		for i, j := 8, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+		return b
+	}
+
+	code, err := verify("bn254", vkBytes.Bytes(), proofBytes.Bytes(), input(35))
+	assert.NoError(err)
+	assert.Equal(groth16.CodeOK, code)
+
+	code, err = verify("bn254", vkBytes.Bytes(), proofBytes.Bytes(), input(36))
+	assert.NoError(err)
+	assert.Equal(groth16.CodePairingFailed, code)
+
+	_, err = verify("bn254", vkBytes.Bytes()[:10], proofBytes.Bytes(), input(35))
+	assert.Error(err)
+	_, err = verify("secp256k1", vkBytes.Bytes(), proofBytes.Bytes(), input(35))
+	assert.Error(err)
+
+	// snarkjs generatecall output
+	p := proof.(*groth16_bn254.Proof)
+	word := func(e *fp.Element) string { return fmt.Sprintf("\"0x%064x\"", e.BigInt(new(big.Int))) }
+	pair := func(a, b *fp.Element) string { return "[" + word(a) + "," + word(b) + "]" }
+	calldata := strings.Join([]string{
+		pair(&p.Ar.X, &p.Ar.Y),
+		"[" + pair(&p.Bs.X.A1, &p.Bs.X.A0) + "," + pair(&p.Bs.Y.A1, &p.Bs.Y.A0) + "]",
+		pair(&p.Krs.X, &p.Krs.Y),
+		fmt.Sprintf("[\"0x%064x\"]", 35),
+	}, ",")
+	code, err = verifySnarkjs(vkBytes.Bytes(), calldata)
+	assert.NoError(err)
+	assert.Equal(groth16.CodeOK, code)
+}
+
+func TestVerifyBLS12381(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit cubic.Circuit
+	ccs, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &circuit)
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	w, err := frontend.NewWitness(&cubic.Circuit{X: 3, Y: 35}, ecc.BLS12_381.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, w)
+	assert.NoError(err)
+
+	// arkworks layouts: compressed A, B, C for the proof
+	var vkBytes bytes.Buffer
+	_, err = vk.(*groth16_bls12381.VerifyingKey).WriteArkworksTo(&vkBytes)
+	assert.NoError(err)
+	p := proof.(*groth16_bls12381.Proof)
+	ar, bs, krs := p.Ar.Bytes(), p.Bs.Bytes(), p.Krs.Bytes()
+	proofBytes := append(append(ar[:], bs[:]...), krs[:]...)
+
+	input := func(v int64) []byte {
+		var e fr_bls12381.Element
+		e.SetInt64(v)
+		b := e.Bytes()
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+		return append([]byte{1, 0, 0, 0, 0, 0, 0, 0}, b[:]...)
+	}
+
+	code, err := verify("bls12_381", vkBytes.Bytes(), proofBytes, input(35))
+	assert.NoError(err)
+	assert.Equal(groth16.CodeOK, code)
+
+	code, err = verify("bls12_381", vkBytes.Bytes(), proofBytes, input(36))
+	assert.NoError(err)
+	assert.Equal(groth16.CodePairingFailed, code)
+
+	// gnark layout keys aren't read on BLS12-381
+	var gnarkBytes bytes.Buffer
+	_, err = vk.WriteTo(&gnarkBytes)
+	assert.NoError(err)
+	_, err = verify("bls12_381", gnarkBytes.Bytes(), proofBytes, input(35))
+	assert.Error(err)
+}
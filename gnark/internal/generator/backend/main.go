@@ -155,12 +155,26 @@ func main() {
 			}
 
 			entries = []bavard.Entry{
-				{File: filepath.Join(groth16Dir, "verify.go"), Templates: []string{"groth16/groth16.verify.go.tmpl", importCurve}},
 				{File: filepath.Join(groth16Dir, "prove.go"), Templates: []string{"groth16/groth16.prove.go.tmpl", importCurve}},
-				{File: filepath.Join(groth16Dir, "setup.go"), Templates: []string{"groth16/groth16.setup.go.tmpl", importCurve}},
-				{File: filepath.Join(groth16Dir, "marshal.go"), Templates: []string{"groth16/groth16.marshal.go.tmpl", importCurve}},
+				{File: filepath.Join(groth16Dir, "metrics.go"), Templates: []string{"groth16/groth16.metrics.go.tmpl", importCurve}},
 				{File: filepath.Join(groth16Dir, "marshal_test.go"), Templates: []string{"groth16/tests/groth16.marshal.go.tmpl", importCurve}},
 			}
+			if d.Curve != "BLS12-381" {
+				// the BLS12-381 verifier reads arkworks keys and has features the
+				// other curves don't, its verify, setup and marshal are hand-written
+				entries = append(entries,
+					bavard.Entry{File: filepath.Join(groth16Dir, "verify.go"), Templates: []string{"groth16/groth16.verify.go.tmpl", importCurve}},
+					bavard.Entry{File: filepath.Join(groth16Dir, "setup.go"), Templates: []string{"groth16/groth16.setup.go.tmpl", importCurve}},
+					bavard.Entry{File: filepath.Join(groth16Dir, "marshal.go"), Templates: []string{"groth16/groth16.marshal.go.tmpl", importCurve}},
+				)
+			}
+			if d.Curve == "BN254" || d.Curve == "BLS12-381" {
+				entries = append(entries,
+					bavard.Entry{File: filepath.Join(groth16Dir, "affine.go"), Templates: []string{"groth16/groth16.affine.go.tmpl", importCurve}},
+					bavard.Entry{File: filepath.Join(groth16Dir, "kzg_inputs.go"), Templates: []string{"groth16/groth16.kzg_inputs.go.tmpl", importCurve}},
+					bavard.Entry{File: filepath.Join(groth16Dir, "kzg_inputs_test.go"), Templates: []string{"groth16/tests/groth16.kzg_inputs.go.tmpl", importCurve}},
+				)
+			}
 			if err := bgen.Generate(d, "groth16", "./template/zkpschemes/", entries...); err != nil {
 				panic(err) // TODO handle
 			}
@@ -0,0 +1,53 @@
+package groth16
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// RecursionWitness is the assignment consumed by an outer circuit verifying a
+// Groth16 proof with [Verifier.AssertProof]. The fields map one to one to the
+// arguments of AssertProof:
+//
+//	verifier.AssertProof(w.VerifyingKey, w.Proof, w.Witness)
+//
+// The verifying key carries the precomputed pairing e(α, β) and the negated
+// [γ]₂ and [δ]₂ used in the in-circuit pairing check, see [ValueOfVerifyingKey].
+type RecursionWitness[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Proof        Proof[G1El, G2El]
+	VerifyingKey VerifyingKey[G1El, G2El, GtEl]
+	Witness      Witness[FR]
+}
+
+// VerificationWitness verifies the proof natively and, if it is valid, returns
+// the assignment for an outer circuit verifying the same proof recursively. It
+// returns an error if the proof doesn't verify or if there is a mismatch
+// between the type parameters and the native proof, key or witness.
+//
+// If the inner proof was computed with [GetNativeProverOptions], then the
+// matching [GetNativeVerifierOptions] must be given in opts.
+func VerificationWitness[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](proof groth16.Proof, vk groth16.VerifyingKey, w witness.Witness, opts ...backend.VerifierOption) (RecursionWitness[FR, G1El, G2El, GtEl], error) {
+	var ret RecursionWitness[FR, G1El, G2El, GtEl]
+	pubw, err := w.Public()
+	if err != nil {
+		return ret, fmt.Errorf("get public witness: %w", err)
+	}
+	if err := groth16.Verify(proof, vk, pubw, opts...); err != nil {
+		return ret, fmt.Errorf("verify: %w", err)
+	}
+	if ret.Proof, err = ValueOfProof[G1El, G2El](proof); err != nil {
+		return ret, fmt.Errorf("proof: %w", err)
+	}
+	if ret.VerifyingKey, err = ValueOfVerifyingKey[G1El, G2El, GtEl](vk); err != nil {
+		return ret, fmt.Errorf("verifying key: %w", err)
+	}
+	if ret.Witness, err = ValueOfWitness[FR](pubw); err != nil {
+		return ret, fmt.Errorf("witness: %w", err)
+	}
+	return ret, nil
+}
@@ -156,6 +156,26 @@ func TestBW6InBN254(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestVerificationWitness(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, innerVK, innerWitness, innerProof := getInner(assert, ecc.BN254.ScalarField())
+
+	assignment, err := VerificationWitness[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](innerProof, innerVK, innerWitness)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfWitness[sw_bn254.ScalarField](innerWitness)
+	assert.NoError(err)
+	assert.Equal(circuitWitness, assignment.Witness)
+	circuitProof, err := ValueOfProof[sw_bn254.G1Affine, sw_bn254.G2Affine](innerProof)
+	assert.NoError(err)
+	assert.Equal(circuitProof, assignment.Proof)
+
+	// a proof which doesn't verify natively doesn't yield an assignment
+	wrongWitness, err := frontend.NewWitness(&InnerCircuit{N: 16}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	assert.NoError(err)
+	_, err = VerificationWitness[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](innerProof, innerVK, wrongWitness)
+	assert.Error(err)
+}
+
 // assignment tests
 
 type WitnessCircut struct {